@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/badimirzai/robotics-verifier-cli/internal/model"
+	"github.com/badimirzai/robotics-verifier-cli/internal/output"
+	"github.com/badimirzai/robotics-verifier-cli/internal/validate"
+	"github.com/spf13/cobra"
+)
+
+var estimateCmd = &cobra.Command{
+	Use:   "estimate",
+	Short: "Estimate operating figures from a robot spec",
+}
+
+var estimateRuntimeCmd = newEstimateRuntimeCmd()
+
+func newEstimateRuntimeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "runtime <spec.yaml>",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "Estimate battery runtime from a duty cycle profile",
+		Long: `Estimate battery runtime from power.battery.capacity_ah and a duty cycle profile.
+
+The profile splits time between idle (motors off), cruise (motors at nominal
+current) and stall. It is read from duty_cycle in the spec; the --idle,
+--cruise and --stall flags override it. Percentages must sum to 100.
+
+Examples:
+  rv estimate runtime robot.yaml
+  rv estimate runtime robot.yaml --idle 50 --cruise 45 --stall 5
+  rv estimate runtime robot.yaml --output json --pretty`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputFormat := strings.ToLower(strings.TrimSpace(getOutputFormat(cmd)))
+			prettyOutput, _ := cmd.Flags().GetBool("pretty")
+			outFile, _ := cmd.Flags().GetString("out-file")
+
+			path, _ := cmd.Flags().GetString("file")
+			if path == "" && len(args) > 0 {
+				path = args[0]
+			}
+			if path == "" {
+				return handleCheckError(outputFormat, 3, "", fmt.Errorf("missing spec file (arg or -f/--file)"), nil, prettyOutput, outFile)
+			}
+			if outFile != "" && outputFormat != "json" {
+				return handleCheckError(outputFormat, 3, path, fmt.Errorf("--out-file requires --output json"), nil, prettyOutput, outFile)
+			}
+			if prettyOutput && outputFormat != "json" {
+				return handleCheckError(outputFormat, 3, path, fmt.Errorf("--pretty requires --output json"), nil, prettyOutput, outFile)
+			}
+
+			partsDirs, _ := cmd.Flags().GetStringArray("parts-dir")
+			resolved, locs, err := loadSpec(path, partsDirs)
+			if err != nil {
+				return handleCheckError(outputFormat, 3, path, err, nil, prettyOutput, outFile)
+			}
+			applyDutyCycleFlags(cmd, &resolved.DutyCycle)
+
+			rep := validate.EstimateRuntime(resolved, locs)
+			exitCode := 0
+			if rep.HasErrors() {
+				exitCode = 2
+			}
+
+			if outputFormat == "json" {
+				if err := renderJSONOutputs(path, rep, exitCode, prettyOutput, outFile, nil); err != nil {
+					return err
+				}
+			} else {
+				fmt.Println(output.RenderTitledReport("rv estimate runtime", rep))
+				printExitCode(exitCode)
+			}
+
+			if exitCode != 0 {
+				return silentExit(exitCode)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringP("file", "f", "", "Path to YAML spec")
+	cmd.Flags().StringP("output", "o", "text", "Output format: text or json")
+	cmd.Flags().Bool("pretty", false, "Pretty print JSON to stdout (requires --output json)")
+	cmd.Flags().String("out-file", "", "Write compact JSON to file (requires --output json)")
+	cmd.Flags().StringArray("parts-dir", nil, "Additional parts directory (repeatable; after rv_parts and built-in parts)")
+	cmd.Flags().Float64("idle", 0, "Percent of time idle (overrides duty_cycle.idle_pct)")
+	cmd.Flags().Float64("cruise", 0, "Percent of time at nominal motor current (overrides duty_cycle.cruise_pct)")
+	cmd.Flags().Float64("stall", 0, "Percent of time stalled (overrides duty_cycle.stall_pct)")
+	return cmd
+}
+
+// applyDutyCycleFlags overrides the duty cycle fields whose flags were given and keeps
+// the spec values for the rest.
+func applyDutyCycleFlags(cmd *cobra.Command, d *model.DutyCycle) {
+	if cmd.Flags().Changed("idle") {
+		d.IdlePct, _ = cmd.Flags().GetFloat64("idle")
+	}
+	if cmd.Flags().Changed("cruise") {
+		d.CruisePct, _ = cmd.Flags().GetFloat64("cruise")
+	}
+	if cmd.Flags().Changed("stall") {
+		d.StallPct, _ = cmd.Flags().GetFloat64("stall")
+	}
+}
+
+func init() {
+	estimateCmd.AddCommand(estimateRuntimeCmd)
+	rootCmd.AddCommand(estimateCmd)
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const runtimeSpecYAML = `name: "runtime"
+power:
+  battery:
+    voltage_v: 12
+    capacity_ah: 5
+  logic_rail:
+    voltage_v: 5
+    max_current_a: 1
+mcu:
+  logic_voltage_v: 5
+motor_driver:
+  channels: 2
+  motor_supply_min_v: 6
+  motor_supply_max_v: 16
+  logic_voltage_min_v: 4.5
+  logic_voltage_max_v: 5.5
+  continuous_per_channel_a: 2
+  peak_per_channel_a: 6
+motors:
+  - name: "wheel"
+    count: 2
+    nominal_current_a: 1
+    stall_current_a: 3
+duty_cycle:
+  idle_pct: 60
+  cruise_pct: 35
+  stall_pct: 5
+`
+
+type runtimeJSON struct {
+	SpecFile string `json:"spec_file"`
+	Summary  struct {
+		Errors   int `json:"errors"`
+		ExitCode int `json:"exit_code"`
+	} `json:"summary"`
+	Findings []struct {
+		ID       string         `json:"id"`
+		Severity string         `json:"severity"`
+		Message  string         `json:"message"`
+		Meta     map[string]any `json:"meta"`
+	} `json:"findings"`
+}
+
+// runEstimateRuntime runs rv estimate runtime with JSON output and returns the report
+// written to --out-file.
+func runEstimateRuntime(t *testing.T, args ...string) (runtimeJSON, error) {
+	t.Helper()
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "robot.yaml")
+	if err := os.WriteFile(specPath, []byte(runtimeSpecYAML), 0o644); err != nil {
+		t.Fatalf("write spec: %v", err)
+	}
+	outPath := filepath.Join(dir, "report.json")
+
+	cmd := newEstimateRuntimeCmd()
+	cmd.SetArgs(append([]string{specPath, "--output", "json", "--out-file", outPath}, args...))
+	runErr := cmd.Execute()
+
+	data, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("read report: %v", err)
+	}
+	var rep runtimeJSON
+	if err := json.Unmarshal(data, &rep); err != nil {
+		t.Fatalf("decode report: %v\n%s", err, data)
+	}
+	return rep, runErr
+}
+
+func findingMessage(rep runtimeJSON, id string) (string, bool) {
+	for _, f := range rep.Findings {
+		if f.ID == id {
+			return f.Message, true
+		}
+	}
+	return "", false
+}
+
+func TestEstimateRuntime_FlagsOverrideOnlyGivenFields(t *testing.T) {
+	rep, err := runEstimateRuntime(t, "--idle", "55", "--stall", "10")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, ok := findingMessage(rep, "RUNTIME_PROFILE_INVALID"); ok {
+		t.Fatalf("expected the spec cruise_pct to be kept, got %+v", rep.Findings)
+	}
+	for state, pct := range map[string]string{"idle": "55.0%", "cruise": "35.0%", "stall": "10.0%"} {
+		found := false
+		for _, f := range rep.Findings {
+			if f.ID == "RUNTIME_STATE" && strings.HasPrefix(f.Message, state+":") {
+				found = strings.Contains(f.Message, pct)
+			}
+		}
+		if !found {
+			t.Errorf("expected %s at %s, got %+v", state, pct, rep.Findings)
+		}
+	}
+}
+
+func TestEstimateRuntime_ProfileMustSumTo100(t *testing.T) {
+	rep, err := runEstimateRuntime(t, "--stall", "10")
+	var exitErr *ExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != 2 {
+		t.Fatalf("expected exit code 2, got %v", err)
+	}
+	msg, ok := findingMessage(rep, "RUNTIME_PROFILE_INVALID")
+	if !ok {
+		t.Fatalf("expected RUNTIME_PROFILE_INVALID, got %+v", rep.Findings)
+	}
+	if !strings.Contains(msg, "60.0 + 35.0 + 10.0") {
+		t.Errorf("expected spec idle and cruise with the stall override, got %q", msg)
+	}
+}
+
+func TestEstimateRuntime_JSONShape(t *testing.T) {
+	rep, err := runEstimateRuntime(t)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.HasSuffix(rep.SpecFile, "robot.yaml") {
+		t.Errorf("expected spec_file to name the spec, got %q", rep.SpecFile)
+	}
+	if rep.Summary.Errors != 0 || rep.Summary.ExitCode != 0 {
+		t.Errorf("unexpected summary: %+v", rep.Summary)
+	}
+	if _, ok := findingMessage(rep, "RUNTIME_ESTIMATE"); !ok {
+		t.Fatalf("expected RUNTIME_ESTIMATE, got %+v", rep.Findings)
+	}
+	for _, f := range rep.Findings {
+		if f.Severity == "" || f.Meta == nil {
+			t.Errorf("expected severity and meta on every finding, got %+v", f)
+		}
+	}
+}
@@ -24,7 +24,7 @@ func TestInitListTemplates(t *testing.T) {
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
-	for _, name := range []string{"4wd-problem", "4wd-clean"} {
+	for _, name := range []string{"4wd-problem", "4wd-clean", "stepper-arm"} {
 		if !strings.Contains(output, name) {
 			t.Fatalf("expected template %q in output, got %q", name, output)
 		}
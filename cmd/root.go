@@ -19,6 +19,7 @@ var rootCmd = &cobra.Command{
 Quick help:
   rv check <file.yaml>       Run analysis
   rv check --output json     Emit JSON findings
+  rv estimate runtime <file> Estimate battery runtime
   rv version                 Show installed version
   rv --help                  Show all commands and flags`,
 	Aliases:       []string{"robotics-verifier-cli"},
@@ -76,31 +76,12 @@ Examples:
 			return handleCheckError(outputFormat, 3, path, fmt.Errorf("--pretty requires --output json"), nil, prettyOutput, outFile)
 		}
 
-		b, err := os.ReadFile(path)
-		if err != nil {
-			return handleCheckError(outputFormat, 3, path, fmt.Errorf("read spec: %w", err), nil, prettyOutput, outFile)
-		}
-
-		var raw model.RobotSpec
-		var doc yaml.Node
-		if err := yaml.Unmarshal(b, &doc); err != nil {
-			return handleCheckError(outputFormat, 3, path, fmt.Errorf("parse yaml: %w", err), nil, prettyOutput, outFile)
-		}
-		if err := doc.Decode(&raw); err != nil {
-			return handleCheckError(outputFormat, 3, path, fmt.Errorf("decode yaml: %w", err), nil, prettyOutput, outFile)
-		}
-
 		partsDirs, _ := cmd.Flags().GetStringArray("parts-dir")
-		store, err := buildPartsStore(partsDirs, os.Getenv("RV_PARTS_DIRS"))
-		if err != nil {
-			return handleCheckError(outputFormat, 3, path, fmt.Errorf("build parts search paths: %w", err), nil, prettyOutput, outFile)
-		}
-		resolved, err := resolve.ResolveAll(raw, store)
+		resolved, locs, err := loadSpec(path, partsDirs)
 		if err != nil {
-			return handleCheckError(outputFormat, 3, path, fmt.Errorf("resolve spec with parts: %w", err), nil, prettyOutput, outFile)
+			return handleCheckError(outputFormat, 3, path, err, nil, prettyOutput, outFile)
 		}
 
-		locs := buildLocationMap(path, &doc)
 		rep := validate.RunAll(resolved, locs)
 		exitCode := 0
 		if rep.HasErrors() {
@@ -132,6 +113,34 @@ func init() {
 	rootCmd.AddCommand(checkCmd)
 }
 
+// loadSpec reads, decodes and resolves a spec against the parts store and maps YAML
+// paths to source locations.
+func loadSpec(path string, partsDirs []string) (model.RobotSpec, map[string]validate.Location, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return model.RobotSpec{}, nil, fmt.Errorf("read spec: %w", err)
+	}
+
+	var raw model.RobotSpec
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return model.RobotSpec{}, nil, fmt.Errorf("parse yaml: %w", err)
+	}
+	if err := doc.Decode(&raw); err != nil {
+		return model.RobotSpec{}, nil, fmt.Errorf("decode yaml: %w", err)
+	}
+
+	store, err := buildPartsStore(partsDirs, os.Getenv("RV_PARTS_DIRS"))
+	if err != nil {
+		return model.RobotSpec{}, nil, fmt.Errorf("build parts search paths: %w", err)
+	}
+	resolved, err := resolve.ResolveAll(raw, store)
+	if err != nil {
+		return model.RobotSpec{}, nil, fmt.Errorf("resolve spec with parts: %w", err)
+	}
+	return resolved, buildLocationMap(path, &doc), nil
+}
+
 func getOutputFormat(cmd *cobra.Command) string {
 	outputFormat, _ := cmd.Flags().GetString("output")
 	if outputFormat == "" {
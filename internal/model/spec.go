@@ -9,26 +9,57 @@ import (
 )
 
 type RobotSpec struct {
-	Name     string      `yaml:"name"`
-	Power    PowerSpec   `yaml:"power"`
-	Motors   []Motor     `yaml:"motors"`
-	Driver   MotorDriver `yaml:"motor_driver"`
-	MCU      MCU         `yaml:"mcu"`
-	I2CBuses []I2CBus    `yaml:"i2c_buses"`
+	Name        string           `yaml:"name"`
+	Environment Environment      `yaml:"environment"`
+	Power       PowerSpec        `yaml:"power"`
+	Motors      []Motor          `yaml:"motors"`
+	Driver      MotorDriver      `yaml:"motor_driver"`  // shorthand for a single driver
+	Drivers     []MotorDriver    `yaml:"motor_drivers"` // robots with more than one driver
+	MCU         MCU              `yaml:"mcu"`
+	Wiring      []Wiring         `yaml:"wiring"` // per-instance motor to channel mapping
+	Steppers    []Stepper        `yaml:"steppers"`
+	BLDC        []BLDC           `yaml:"bldc"`
+	Servos      []Servo          `yaml:"servos"`
+	PWM         []PWMController  `yaml:"pwm_controllers"`
+	I2CBuses    []I2CBus         `yaml:"i2c_buses"`
+	SPIBuses    []SPIBus         `yaml:"spi_buses"`
+	UARTs       []UART           `yaml:"uarts"`
+	CANBuses    []CANBus         `yaml:"can_buses"`
+	Encoders    []Encoder        `yaml:"encoders"`
+	Shifters    []LevelShifter   `yaml:"level_shifters"`
+	Harness     []HarnessSegment `yaml:"harness"`
+	Pins        []PinAssignment  `yaml:"pins"`
+	DutyCycle   DutyCycle        `yaml:"duty_cycle"` // used by rv estimate runtime
+}
+
+// Environment holds operating conditions shared by all parts.
+type Environment struct {
+	AmbientC float64 `yaml:"ambient_c"` // defaults to 25
+}
+
+// DutyCycle splits operating time between motor states, in percent of runtime.
+type DutyCycle struct {
+	IdlePct   float64 `yaml:"idle_pct"`   // motors off
+	CruisePct float64 `yaml:"cruise_pct"` // motors at nominal current
+	StallPct  float64 `yaml:"stall_pct"`  // motors stalled
 }
 
 type PowerSpec struct {
-	Battery Battery `yaml:"battery"`
-	Rail    Rail    `yaml:"logic_rail"` // main logic rail after regulation
+	Battery Battery     `yaml:"battery"`
+	Rail    Rail        `yaml:"logic_rail"` // main logic rail after regulation
+	Rails   []PowerRail `yaml:"rails"`      // optional regulator tree fed from the battery
 }
 
 type Battery struct {
-	Chemistry     string  `yaml:"chemistry"` // e.g. "Li-ion"
-	VoltageV      float64 `yaml:"voltage_v"` // nominal
-	MaxCurrentA   float64 `yaml:"max_current_a"`
-	CapacityAh    float64 `yaml:"capacity_ah"`
-	CRating       float64 `yaml:"c_rating"`
-	MaxDischargeA float64 `yaml:"max_discharge_a"`
+	Chemistry             string  `yaml:"chemistry"` // e.g. "Li-ion", "LiPo", "LiFePO4", "NiMH"
+	VoltageV              float64 `yaml:"voltage_v"` // nominal
+	MaxCurrentA           float64 `yaml:"max_current_a"`
+	CapacityAh            float64 `yaml:"capacity_ah"`
+	CRating               float64 `yaml:"c_rating"`
+	MaxDischargeA         float64 `yaml:"max_discharge_a"`
+	Cells                 int     `yaml:"cells"`                   // series cell count, e.g. 4 for 4S
+	InternalResistanceOhm float64 `yaml:"internal_resistance_ohm"` // whole pack
+	Kind                  string  `yaml:"kind"`                    // "battery" (default) or "psu"; a bench supply cannot sink regenerated current
 }
 
 type Rail struct {
@@ -36,38 +67,255 @@ type Rail struct {
 	MaxCurrentA float64 `yaml:"max_current_a"` // regulator output capability
 }
 
+// PowerRail is one node of the power tree. Source names the battery or another rail,
+// so regulator chains such as 12V -> 5V buck -> 3.3V LDO can be described.
+type PowerRail struct {
+	Name        string  `yaml:"name"`
+	Source      string  `yaml:"source"`    // "battery" (default) or the name of another rail
+	Regulator   string  `yaml:"regulator"` // "buck", "boost", "buck_boost", "ldo" or "none"
+	VoltageV    float64 `yaml:"voltage_v"`
+	Efficiency  float64 `yaml:"efficiency"` // switching regulators, 0..1
+	DropoutV    float64 `yaml:"dropout_v"`  // linear regulators
+	MaxCurrentA float64 `yaml:"max_current_a"`
+}
+
 type Motor struct {
-	Part            string  `yaml:"part,omitempty"`
-	Name            string  `yaml:"name"`
-	Count           int     `yaml:"count"`
-	VoltageMinV     float64 `yaml:"voltage_min_v"`
-	VoltageMaxV     float64 `yaml:"voltage_max_v"`
-	StallCurrentA   float64 `yaml:"stall_current_a"`
-	NominalCurrentA float64 `yaml:"nominal_current_a"`
+	Part            string       `yaml:"part,omitempty"`
+	Name            string       `yaml:"name"`
+	Count           int          `yaml:"count"`
+	VoltageMinV     float64      `yaml:"voltage_min_v"`
+	VoltageMaxV     float64      `yaml:"voltage_max_v"`
+	StallCurrentA   float64      `yaml:"stall_current_a"`
+	NominalCurrentA float64      `yaml:"nominal_current_a"`
+	RatedVoltageV   float64      `yaml:"rated_voltage_v"`    // voltage stall_current_a is specified at
+	Driver          string       `yaml:"driver,omitempty"`   // motor driver name; optional with a single driver
+	Channels        []int        `yaml:"channels,omitempty"` // 1-based driver channel per motor instance
+	MaxDutyPct      float64      `yaml:"max_duty_pct"`       // firmware PWM duty cap, defaults to 100
+	NoLoadRPM       float64      `yaml:"no_load_rpm"`        // output shaft at rated_voltage_v
+	GearRatio       float64      `yaml:"gear_ratio"`         // motor turns per output turn, defaults to 1
+	Encoder         MotorEncoder `yaml:"encoder"`            // fitted encoder, usually from the part
+
+	RotorInertiaKgM2 float64 `yaml:"rotor_inertia_kgm2"` // motor shaft
+	LoadInertiaKgM2  float64 `yaml:"load_inertia_kgm2"`  // wheel or arm at the output shaft
+}
+
+// MotorEncoder describes a quadrature encoder on a motor shaft.
+type MotorEncoder struct {
+	PPR           float64       `yaml:"ppr"` // pulses per motor shaft turn, per channel
+	SupplyMinV    float64       `yaml:"supply_min_v"`
+	SupplyMaxV    float64       `yaml:"supply_max_v"`
+	Output        string        `yaml:"output"` // push_pull or open_collector
+	SupplyCurrent SupplyCurrent `yaml:"supply_current"`
+}
+
+// Wiring maps one motor instance to driver channels. Listing several channels
+// parallels them to share the motor current; wiring several instances to the same
+// channel makes them share its rating.
+type Wiring struct {
+	Motor    string `yaml:"motor"`            // motors[].name
+	Instance int    `yaml:"instance"`         // 1-based, up to motors[].count
+	Driver   string `yaml:"driver,omitempty"` // defaults to motors[].driver
+	Channels []int  `yaml:"channels"`         // 1-based driver channels
 }
 
 type MotorDriver struct {
-	Part             string  `yaml:"part,omitempty"`
-	Name             string  `yaml:"name"`
-	MotorSupplyMinV  float64 `yaml:"motor_supply_min_v"`
-	MotorSupplyMaxV  float64 `yaml:"motor_supply_max_v"`
-	ContinuousPerChA float64 `yaml:"continuous_per_channel_a"`
-	PeakPerChA       float64 `yaml:"peak_per_channel_a"`
-	Channels         int     `yaml:"channels"`
-	LogicVoltageMinV float64 `yaml:"logic_voltage_min_v"`
-	LogicVoltageMaxV float64 `yaml:"logic_voltage_max_v"`
+	Part             string        `yaml:"part,omitempty"`
+	Name             string        `yaml:"name"`
+	MotorSupplyMinV  float64       `yaml:"motor_supply_min_v"`
+	MotorSupplyMaxV  float64       `yaml:"motor_supply_max_v"`
+	ContinuousPerChA float64       `yaml:"continuous_per_channel_a"`
+	PeakPerChA       float64       `yaml:"peak_per_channel_a"`
+	Channels         int           `yaml:"channels"`
+	LogicVoltageMinV float64       `yaml:"logic_voltage_min_v"`
+	LogicVoltageMaxV float64       `yaml:"logic_voltage_max_v"`
+	LogicRail        string        `yaml:"logic_rail,omitempty"`  // power.rails name; empty uses power.logic_rail
+	SupplyRail       string        `yaml:"supply_rail,omitempty"` // power.rails name; empty uses the battery
+	LogicCurrent     SupplyCurrent `yaml:"logic_current"`         // drawn from the logic rail
+	ParallelDerating float64       `yaml:"parallel_derating"`     // usable fraction of summed ratings when channels are paralleled
+
+	// Thermal data. Conduction loss comes from rds_on_ohm (MOSFET bridges) or
+	// saturation_drop_v (bipolar bridges like the L298).
+	RdsOnOhm                float64 `yaml:"rds_on_ohm"`                // per switch; a channel conducts through two
+	SaturationDropV         float64 `yaml:"saturation_drop_v"`         // total high plus low side drop per channel
+	SaturationResistanceOhm float64 `yaml:"saturation_resistance_ohm"` // growth of the saturation drop with current
+	ThetaJACPerW            float64 `yaml:"theta_ja_c_per_w"`
+	TjMaxC                  float64 `yaml:"tj_max_c"`
+	Heatsink                bool    `yaml:"heatsink"`
+	ThetaJAHeatsinkCPerW    float64 `yaml:"theta_ja_heatsink_c_per_w"` // used when heatsink is true
+
+	LogicInputs IOLevels `yaml:"logic_inputs"`           // input thresholds of the control pins
+	MCUIOGroup  string   `yaml:"mcu_io_group,omitempty"` // MCU io_groups name driving it; empty uses the first
+
+	// Regenerative braking. Decelerating motors push energy back onto the motor
+	// supply; these absorb or clamp it.
+	BulkCapUF        float64 `yaml:"bulk_cap_uf"`        // capacitance across the motor supply pins
+	TVSClampV        float64 `yaml:"tvs_clamp_v"`        // TVS clamping voltage at the regen current
+	BrakeResistorOhm float64 `yaml:"brake_resistor_ohm"` // brake chopper resistor
+	RegenFraction    float64 `yaml:"regen_fraction"`     // bus rise as a share of the supply without inertia data, default 0.25
+}
+
+// Stepper is a stepper axis: a motor and the driver that runs it. Each of the
+// count instances has its own driver.
+type Stepper struct {
+	Name   string        `yaml:"name"`
+	Count  int           `yaml:"count"`
+	Motor  StepperMotor  `yaml:"motor"`
+	Driver StepperDriver `yaml:"driver"`
+}
+
+type StepperMotor struct {
+	Part               string  `yaml:"part,omitempty"`
+	Name               string  `yaml:"name"`
+	PhaseCurrentA      float64 `yaml:"phase_current_a"` // rated current per phase
+	PhaseResistanceOhm float64 `yaml:"phase_resistance_ohm"`
+	RatedVoltageV      float64 `yaml:"rated_voltage_v"` // phase_current_a x phase_resistance_ohm when unset
+	StepAngleDeg       float64 `yaml:"step_angle_deg"`  // full step, e.g. 1.8
+}
+
+type StepperDriver struct {
+	Part                string        `yaml:"part,omitempty"`
+	Name                string        `yaml:"name"`
+	MotorSupplyMinV     float64       `yaml:"motor_supply_min_v"` // VMOT
+	MotorSupplyMaxV     float64       `yaml:"motor_supply_max_v"`
+	MaxPhaseCurrentA    float64       `yaml:"max_phase_current_a"`
+	CurrentLimitA       float64       `yaml:"current_limit_a"` // configured limit (Vref or UART setting)
+	Microsteps          int           `yaml:"microsteps"`      // configured resolution, 16 for 1/16 steps
+	SupportedMicrosteps []int         `yaml:"supported_microsteps"`
+	LogicVoltageMinV    float64       `yaml:"logic_voltage_min_v"`
+	LogicVoltageMaxV    float64       `yaml:"logic_voltage_max_v"`
+	LogicRail           string        `yaml:"logic_rail,omitempty"`  // power.rails name; empty uses power.logic_rail
+	SupplyRail          string        `yaml:"supply_rail,omitempty"` // power.rails name; empty uses the battery
+	LogicCurrent        SupplyCurrent `yaml:"logic_current"`
+}
+
+// BLDC is a brushless drive: a motor and the ESC that runs it. Each of the count
+// instances has its own ESC.
+type BLDC struct {
+	Name  string    `yaml:"name"`
+	Count int       `yaml:"count"`
+	Motor BLDCMotor `yaml:"motor"`
+	ESC   ESC       `yaml:"esc"`
+}
+
+type BLDCMotor struct {
+	Part        string  `yaml:"part,omitempty"`
+	Name        string  `yaml:"name"`
+	KV          float64 `yaml:"kv"` // rpm per volt
+	MaxCurrentA float64 `yaml:"max_current_a"`
+	MinCells    int     `yaml:"min_cells"`
+	MaxCells    int     `yaml:"max_cells"`
+}
+
+type ESC struct {
+	Part        string  `yaml:"part,omitempty"`
+	Name        string  `yaml:"name"`
+	ContinuousA float64 `yaml:"continuous_a"`
+	BurstA      float64 `yaml:"burst_a"`
+	MinCells    int     `yaml:"min_cells"`
+	MaxCells    int     `yaml:"max_cells"`
+	BEC         BEC     `yaml:"bec"`
+}
+
+// BEC is the regulated output some ESCs provide for the flight controller or MCU.
+type BEC struct {
+	VoltageV    float64 `yaml:"voltage_v"`
+	MaxCurrentA float64 `yaml:"max_current_a"`
+	Feeds       string  `yaml:"feeds,omitempty"` // "logic_rail" or a power.rails name; empty when unused
+}
+
+type Servo struct {
+	Part          string  `yaml:"part,omitempty"`
+	Name          string  `yaml:"name"`
+	Count         int     `yaml:"count"`
+	VoltageMinV   float64 `yaml:"voltage_min_v"`
+	VoltageMaxV   float64 `yaml:"voltage_max_v"`
+	IdleCurrentA  float64 `yaml:"idle_current_a"`
+	StallCurrentA float64 `yaml:"stall_current_a"`
+	SignalMinV    float64 `yaml:"signal_min_v"`          // lowest pulse high level the servo accepts
+	SignalMaxV    float64 `yaml:"signal_max_v"`          // highest pulse level, usually the supply voltage
+	SupplyRail    string  `yaml:"supply_rail,omitempty"` // power.rails name; empty uses the battery
+	Controller    string  `yaml:"controller,omitempty"`  // pwm_controllers name; empty when driven from MCU pins
+}
+
+// PWMController is a PCA9685-style I2C PWM expander. It sits on an I2C bus like any
+// other device and drives servo signals at its own supply voltage.
+type PWMController struct {
+	Part             string             `yaml:"part,omitempty"`
+	Name             string             `yaml:"name"`
+	Bus              string             `yaml:"bus"` // i2c_buses name
+	AddressHex       I2CAddress         `yaml:"address_hex"`
+	Channels         int                `yaml:"channels"`
+	LogicVoltageMinV float64            `yaml:"logic_voltage_min_v"`
+	LogicVoltageMaxV float64            `yaml:"logic_voltage_max_v"`
+	Rail             string             `yaml:"rail,omitempty"` // power.rails name; empty uses the bus rail
+	SupplyCurrent    SupplyCurrent      `yaml:"supply_current"`
+	PinCapacitancePF float64            `yaml:"pin_capacitance_pf"`
+	MaxSpeedKHz      float64            `yaml:"max_speed_khz"`
+	Addresses        []I2CAddressOption `yaml:"addresses,omitempty"` // selectable addresses, from the part
 }
 
 type MCU struct {
-	Part             string  `yaml:"part,omitempty"`
-	Name             string  `yaml:"name"`
-	LogicVoltageV    float64 `yaml:"logic_voltage_v"` // usually 3.3 for ESP32
-	MaxGPIOCurrentmA float64 `yaml:"max_gpio_current_ma"`
+	Part             string        `yaml:"part,omitempty"`
+	Name             string        `yaml:"name"`
+	LogicVoltageV    float64       `yaml:"logic_voltage_v"` // usually 3.3 for ESP32
+	MaxGPIOCurrentmA float64       `yaml:"max_gpio_current_ma"`
+	SupplyMinV       float64       `yaml:"supply_min_v"`
+	SupplyMaxV       float64       `yaml:"supply_max_v"`
+	Rail             string        `yaml:"rail,omitempty"` // power.rails name; empty uses power.logic_rail
+	SupplyCurrent    SupplyCurrent `yaml:"supply_current"`
+	UARTs            []string      `yaml:"uarts"`                     // UART instances, e.g. ["UART0", "UART1"]
+	UARTMaxBaud      int           `yaml:"uart_max_baud"`             // highest baud rate the UARTs can generate
+	FiveVTolerant    *bool         `yaml:"five_v_tolerant,omitempty"` // all IO pins accept 5V inputs; set, it overrides the part and io_groups
+
+	MaxTotalGPIOCurrentmA float64    `yaml:"max_total_gpio_current_ma"` // all pins together
+	Pins                  []MCUPin   `yaml:"pins"`                      // pin table, from the part
+	MaxInterruptRateHz    float64    `yaml:"max_interrupt_rate_hz"`     // pin interrupts per second firmware can service
+	IOGroups              []IOLevels `yaml:"io_groups"`                 // logic thresholds per group of pins, from the part
+}
+
+// IOLevels are the logic thresholds of one group of pins: guaranteed output levels at
+// rated load and the input levels read as high or low.
+type IOLevels struct {
+	Name          string  `yaml:"name,omitempty"`
+	VOHV          float64 `yaml:"voh_v"`       // minimum output high
+	VOLV          float64 `yaml:"vol_v"`       // maximum output low
+	VIHV          float64 `yaml:"vih_v"`       // minimum input read as high
+	VILV          float64 `yaml:"vil_v"`       // maximum input read as low
+	MaxInputV     float64 `yaml:"max_input_v"` // absolute maximum on an input; 0 derives it from the supply
+	FiveVTolerant bool    `yaml:"five_v_tolerant"`
+}
+
+// MCUPin is one pin in an MCU pin table.
+type MCUPin struct {
+	Name string   `yaml:"name"` // as used in pins[].pin, e.g. "GPIO4" or "D3"
+	Caps []string `yaml:"caps"` // gpio, pwm, adc, i2c, spi, uart, interrupt, strapping, input_only
+	Note string   `yaml:"note,omitempty"`
+}
+
+// PinAssignment claims an MCU pin for one signal.
+type PinAssignment struct {
+	Pin       string  `yaml:"pin"`
+	Function  string  `yaml:"function"` // pwm, output, input, adc, i2c, spi, uart
+	Use       string  `yaml:"use"`      // what it connects to, e.g. "left driver PWMA"
+	CurrentmA float64 `yaml:"current_ma"`
+}
+
+// SupplyCurrent describes what a logic consumer draws from the rail powering it.
+type SupplyCurrent struct {
+	QuiescentA float64 `yaml:"quiescent_a"` // idle or sleep
+	TypicalA   float64 `yaml:"typical_a"`   // normal operation
+	PeakA      float64 `yaml:"peak_a"`      // short bursts, e.g. radio TX
 }
 
 type I2CBus struct {
-	Name    string      `yaml:"name"`
-	Devices []I2CDevice `yaml:"devices"`
+	Name           string      `yaml:"name"`
+	Rail           string      `yaml:"rail,omitempty"` // default rail for devices on this bus
+	Devices        []I2CDevice `yaml:"devices"`
+	SpeedKHz       float64     `yaml:"speed_khz"`        // SCL clock, e.g. 100, 400 or 1000
+	PullupOhm      float64     `yaml:"pullup_ohm"`       // effective SDA/SCL pull-up, parallel modules combined
+	PullupVoltageV float64     `yaml:"pullup_voltage_v"` // defaults to the bus rail voltage
+	CapacitancePF  float64     `yaml:"capacitance_pf"`   // traces and cables, excluding device pins
+	TraceLengthCm  float64     `yaml:"trace_length_cm"`  // used when capacitance_pf is not declared
 }
 
 type I2CAddress uint16
@@ -90,7 +338,147 @@ func (a *I2CAddress) UnmarshalYAML(value *yaml.Node) error {
 }
 
 type I2CDevice struct {
-	Part       string     `yaml:"part,omitempty"`
-	Name       string     `yaml:"name"`
+	Part             string             `yaml:"part,omitempty"`
+	Name             string             `yaml:"name"`
+	AddressHex       I2CAddress         `yaml:"address_hex"`
+	Rail             string             `yaml:"rail,omitempty"` // power.rails name; empty uses the bus rail
+	SupplyCurrent    SupplyCurrent      `yaml:"supply_current"`
+	PinCapacitancePF float64            `yaml:"pin_capacitance_pf"`
+	MaxSpeedKHz      float64            `yaml:"max_speed_khz"`
+	Addresses        []I2CAddressOption `yaml:"addresses,omitempty"` // selectable addresses, from the part
+
+	// A multiplexer (e.g. TCA9548A) is a device with downstream segments. Its own
+	// address sits on the parent bus; each segment is isolated from its siblings.
+	MuxChannels int          `yaml:"mux_channels,omitempty"`
+	Segments    []I2CSegment `yaml:"segments,omitempty"`
+}
+
+// I2CSegment is the bus behind one multiplexer channel.
+type I2CSegment struct {
+	Channel int         `yaml:"channel"`
+	Devices []I2CDevice `yaml:"devices"`
+}
+
+// I2CAddressOption is one address a device can be strapped to.
+type I2CAddressOption struct {
 	AddressHex I2CAddress `yaml:"address_hex"`
+	Strap      string     `yaml:"strap"` // how to select it, e.g. "AD0 high"
+}
+
+// SPIBus is one SPI controller: SCK, MOSI and MISO shared by every device, with a
+// chip select pin per device.
+type SPIBus struct {
+	Name     string      `yaml:"name"`
+	ClockMHz float64     `yaml:"clock_mhz"`      // SCK the firmware configures
+	Rail     string      `yaml:"rail,omitempty"` // default rail for devices on this bus
+	Note     string      `yaml:"note,omitempty"` // e.g. why devices use different SPI modes
+	Devices  []SPIDevice `yaml:"devices"`
+}
+
+type SPIDevice struct {
+	Part             string        `yaml:"part,omitempty"`
+	Name             string        `yaml:"name"`
+	CSPin            string        `yaml:"cs_pin"`              // MCU pin driving chip select
+	Mode             *int          `yaml:"mode,omitempty"`      // 0..3; defaults to the first supported mode
+	ClockMHz         float64       `yaml:"clock_mhz,omitempty"` // per device SCK; defaults to the bus clock
+	SupportedModes   []int         `yaml:"supported_modes"`
+	MaxClockMHz      float64       `yaml:"max_clock_mhz"`
+	LogicVoltageMinV float64       `yaml:"logic_voltage_min_v"`
+	LogicVoltageMaxV float64       `yaml:"logic_voltage_max_v"`
+	Rail             string        `yaml:"rail,omitempty"` // power.rails name; empty uses the bus rail
+	SupplyCurrent    SupplyCurrent `yaml:"supply_current"`
+}
+
+// UART maps a serial peripheral (GPS, LiDAR, RC receiver) to an MCU UART instance.
+type UART struct {
+	Part          string        `yaml:"part,omitempty"`
+	Name          string        `yaml:"name"`
+	Instance      string        `yaml:"uart"` // MCU UART instance, e.g. "UART1"
+	Baud          int           `yaml:"baud"` // defaults to the part default_baud
+	SupportedBaud []int         `yaml:"supported_baud"`
+	LogicVoltageV float64       `yaml:"logic_voltage_v"` // TX high level, 5 for 5V TTL
+	Rail          string        `yaml:"rail,omitempty"`  // power.rails name; empty uses power.logic_rail
+	SupplyCurrent SupplyCurrent `yaml:"supply_current"`
+	IO            IOLevels      `yaml:"io"`                     // TX output and RX input thresholds
+	MCUIOGroup    string        `yaml:"mcu_io_group,omitempty"` // MCU io_groups name it connects to; empty uses the first
+}
+
+// CANBus is one CAN network. Terminations are counted from terminations_ohm (fitted
+// resistors) and every node's termination_ohm.
+type CANBus struct {
+	Name            string    `yaml:"name"`
+	BitrateKbps     int       `yaml:"bitrate_kbps"`     // 125, 250, 500 or 1000
+	TerminationsOhm []float64 `yaml:"terminations_ohm"` // standalone resistors on the bus ends
+	Nodes           []CANNode `yaml:"nodes"`
+}
+
+type CANNode struct {
+	Part                  string        `yaml:"part,omitempty"`
+	Name                  string        `yaml:"name"`
+	NodeID                *int          `yaml:"node_id,omitempty"`
+	MaxNodeID             int           `yaml:"max_node_id"` // highest ID the protocol allows, e.g. 127 for CANopen
+	SupportedBitratesKbps []int         `yaml:"supported_bitrates_kbps"`
+	TerminationOhm        float64       `yaml:"termination_ohm"` // onboard terminator when fitted or switched on
+	TransceiverSupplyMinV float64       `yaml:"transceiver_supply_min_v"`
+	TransceiverSupplyMaxV float64       `yaml:"transceiver_supply_max_v"`
+	Rail                  string        `yaml:"rail,omitempty"` // rail feeding the transceiver; empty uses power.logic_rail
+	SupplyCurrent         SupplyCurrent `yaml:"supply_current"`
+}
+
+// Encoder wires a motor's encoder to the MCU. Encoder fields left unset come from
+// the motor's encoder block.
+type Encoder struct {
+	Name         string  `yaml:"name"`
+	Motor        string  `yaml:"motor"` // motors[].name
+	PinA         string  `yaml:"pin_a"`
+	PinB         string  `yaml:"pin_b"`
+	Rail         string  `yaml:"rail,omitempty"` // encoder supply; empty uses power.logic_rail
+	PullupV      float64 `yaml:"pullup_v"`       // open collector pull-up; defaults to MCU logic (internal pull-ups)
+	Decoding     int     `yaml:"decoding"`       // edges counted per pulse: 1, 2 or 4 (default 4)
+	MotorEncoder `yaml:",inline"`
+}
+
+// LevelShifter translates signals between the MCU on its A side and a higher or
+// lower voltage peripheral on its B side.
+type LevelShifter struct {
+	Part            string          `yaml:"part,omitempty"`
+	Name            string          `yaml:"name"`
+	ASideMinV       float64         `yaml:"a_side_min_v"`
+	ASideMaxV       float64         `yaml:"a_side_max_v"`
+	BSideMinV       float64         `yaml:"b_side_min_v"`
+	BSideMaxV       float64         `yaml:"b_side_max_v"`
+	Direction       string          `yaml:"direction"` // bidirectional, a_to_b or b_to_a
+	MaxDataRateMbps float64         `yaml:"max_data_rate_mbps"`
+	Channels        int             `yaml:"channels"`
+	ARail           string          `yaml:"a_rail,omitempty"` // A side reference; empty follows MCU logic
+	BRail           string          `yaml:"b_rail,omitempty"` // B side reference; empty follows the peripheral
+	SupplyCurrent   SupplyCurrent   `yaml:"supply_current"`
+	Signals         []ShiftedSignal `yaml:"signals"`
+}
+
+// ShiftedSignal routes the MCU's signals to one peripheral through a shifter.
+type ShiftedSignal struct {
+	To           string  `yaml:"to"`              // motor driver name (or motor_driver), steppers[].name, uarts[].name, SPI device or encoder name
+	DataRateMbps float64 `yaml:"data_rate_mbps"`  // defaults to the UART baud rate or SPI clock
+	Lines        int     `yaml:"lines,omitempty"` // shifter channels used; defaults to 3 per driver channel, 2 per stepper, 2 for UART, 4 for SPI, 2 for an encoder
+}
+
+// HarnessSegment is one run of power wiring, with the fuse and connectors in series
+// on it.
+type HarnessSegment struct {
+	Name        string      `yaml:"name"`
+	Carries     string      `yaml:"carries"` // "battery" (default), a power.rails name or a motor driver
+	AWG         int         `yaml:"awg"`
+	LengthM     float64     `yaml:"length_m"`      // one way; the return conductor doubles it
+	WireRatingA float64     `yaml:"wire_rating_a"` // overrides the AWG table
+	FuseA       float64     `yaml:"fuse_a"`        // fuse rating; 0 when unfused
+	Connectors  []Connector `yaml:"connectors"`
+}
+
+// Connector is a mated connector pair in series with a harness segment.
+type Connector struct {
+	Part        string  `yaml:"part,omitempty"`
+	Name        string  `yaml:"name"`
+	RatingA     float64 `yaml:"rating_a"`      // continuous, per contact
+	PeakRatingA float64 `yaml:"peak_rating_a"` // short bursts; defaults to rating_a
 }
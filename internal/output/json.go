@@ -64,13 +64,18 @@ func RenderJSONReport(specFile string, report validate.Report, exitCode int, deb
 			location = &jsonLocation{Line: f.Location.Line, Column: column}
 		}
 
+		meta := map[string]interface{}{}
+		for k, v := range f.Meta {
+			meta[k] = v
+		}
+
 		findings = append(findings, jsonFinding{
 			ID:       f.Code,
 			Severity: string(f.Severity),
 			Message:  f.Message,
 			Path:     path,
 			Location: location,
-			Meta:     map[string]interface{}{},
+			Meta:     meta,
 		})
 	}
 
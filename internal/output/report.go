@@ -9,10 +9,15 @@ import (
 )
 
 func RenderReport(r validate.Report) string {
+	return RenderTitledReport("rv check", r)
+}
+
+// RenderTitledReport renders findings under the given command title.
+func RenderTitledReport(title string, r validate.Report) string {
 	var b strings.Builder
-	b.WriteString(ui.Colorize("HEADER", "rv check"))
+	b.WriteString(ui.Colorize("HEADER", title))
 	b.WriteString("\n")
-	b.WriteString(ui.Colorize("HEADER", "--------------"))
+	b.WriteString(ui.Colorize("HEADER", strings.Repeat("-", max(len(title), 14))))
 	b.WriteString("\n")
 	for _, f := range r.Findings {
 		severity := string(f.Severity)
@@ -19,13 +19,22 @@ type DriverPartFile struct {
 	MPN    string `yaml:"mpn"`
 
 	MotorDriver struct {
-		Channels         int     `yaml:"channels"`
-		MotorSupplyMinV  float64 `yaml:"motor_supply_min_v"`
-		MotorSupplyMaxV  float64 `yaml:"motor_supply_max_v"`
-		LogicVoltageMinV float64 `yaml:"logic_voltage_min_v"`
-		LogicVoltageMaxV float64 `yaml:"logic_voltage_max_v"`
-		ContinuousPerChA float64 `yaml:"continuous_per_channel_a"`
-		PeakPerChA       float64 `yaml:"peak_per_channel_a"`
+		Channels                int                 `yaml:"channels"`
+		MotorSupplyMinV         float64             `yaml:"motor_supply_min_v"`
+		MotorSupplyMaxV         float64             `yaml:"motor_supply_max_v"`
+		LogicVoltageMinV        float64             `yaml:"logic_voltage_min_v"`
+		LogicVoltageMaxV        float64             `yaml:"logic_voltage_max_v"`
+		ContinuousPerChA        float64             `yaml:"continuous_per_channel_a"`
+		PeakPerChA              float64             `yaml:"peak_per_channel_a"`
+		LogicCurrent            model.SupplyCurrent `yaml:"logic_current"`
+		ParallelDerating        float64             `yaml:"parallel_derating"`
+		RdsOnOhm                float64             `yaml:"rds_on_ohm"`
+		SaturationDropV         float64             `yaml:"saturation_drop_v"`
+		SaturationResistanceOhm float64             `yaml:"saturation_resistance_ohm"`
+		ThetaJACPerW            float64             `yaml:"theta_ja_c_per_w"`
+		TjMaxC                  float64             `yaml:"tj_max_c"`
+		ThetaJAHeatsinkCPerW    float64             `yaml:"theta_ja_heatsink_c_per_w"`
+		LogicInputs             model.IOLevels      `yaml:"logic_inputs"`
 	} `yaml:"motor_driver"`
 }
 
@@ -37,13 +46,126 @@ type MotorPartFile struct {
 	Name   string `yaml:"name"`
 
 	Motor struct {
-		VoltageMinV     float64 `yaml:"voltage_min_v"`
-		VoltageMaxV     float64 `yaml:"voltage_max_v"`
-		NominalCurrentA float64 `yaml:"nominal_current_a"`
-		StallCurrentA   float64 `yaml:"stall_current_a"`
+		VoltageMinV     float64            `yaml:"voltage_min_v"`
+		VoltageMaxV     float64            `yaml:"voltage_max_v"`
+		NominalCurrentA float64            `yaml:"nominal_current_a"`
+		StallCurrentA   float64            `yaml:"stall_current_a"`
+		RatedVoltageV   float64            `yaml:"rated_voltage_v"`
+		NoLoadRPM       float64            `yaml:"no_load_rpm"`
+		GearRatio       float64            `yaml:"gear_ratio"`
+		Encoder         model.MotorEncoder `yaml:"encoder"`
 	} `yaml:"motor"`
 }
 
+// StepperMotorPartFile represents the YAML structure for a stepper motor.
+// Example: parts/motors/nema17_17hs4401.yaml
+type StepperMotorPartFile struct {
+	PartID string `yaml:"part_id"`
+	Type   string `yaml:"type"`
+	Name   string `yaml:"name"`
+	MPN    string `yaml:"mpn"`
+
+	StepperMotor struct {
+		PhaseCurrentA      float64 `yaml:"phase_current_a"`
+		PhaseResistanceOhm float64 `yaml:"phase_resistance_ohm"`
+		RatedVoltageV      float64 `yaml:"rated_voltage_v"`
+		StepAngleDeg       float64 `yaml:"step_angle_deg"`
+	} `yaml:"stepper_motor"`
+}
+
+// StepperDriverPartFile represents the YAML structure for a stepper driver.
+// Example: parts/drivers/a4988.yaml
+type StepperDriverPartFile struct {
+	PartID string `yaml:"part_id"`
+	Type   string `yaml:"type"`
+	Name   string `yaml:"name"`
+	MPN    string `yaml:"mpn"`
+
+	StepperDriver struct {
+		MotorSupplyMinV     float64             `yaml:"motor_supply_min_v"`
+		MotorSupplyMaxV     float64             `yaml:"motor_supply_max_v"`
+		MaxPhaseCurrentA    float64             `yaml:"max_phase_current_a"`
+		SupportedMicrosteps []int               `yaml:"supported_microsteps"`
+		LogicVoltageMinV    float64             `yaml:"logic_voltage_min_v"`
+		LogicVoltageMaxV    float64             `yaml:"logic_voltage_max_v"`
+		LogicCurrent        model.SupplyCurrent `yaml:"logic_current"`
+	} `yaml:"stepper_driver"`
+}
+
+// BLDCMotorPartFile represents the YAML structure for a brushless motor.
+// Example: parts/motors/bldc_2207_1750kv.yaml
+type BLDCMotorPartFile struct {
+	PartID string `yaml:"part_id"`
+	Type   string `yaml:"type"`
+	Name   string `yaml:"name"`
+	MPN    string `yaml:"mpn"`
+
+	BLDCMotor struct {
+		KV          float64 `yaml:"kv"`
+		MaxCurrentA float64 `yaml:"max_current_a"`
+		MinCells    int     `yaml:"min_cells"`
+		MaxCells    int     `yaml:"max_cells"`
+	} `yaml:"bldc_motor"`
+}
+
+// ESCPartFile represents the YAML structure for an electronic speed controller.
+// Example: parts/drivers/esc_30a_bec.yaml
+type ESCPartFile struct {
+	PartID string `yaml:"part_id"`
+	Type   string `yaml:"type"`
+	Name   string `yaml:"name"`
+	MPN    string `yaml:"mpn"`
+
+	ESC struct {
+		ContinuousA float64 `yaml:"continuous_a"`
+		BurstA      float64 `yaml:"burst_a"`
+		MinCells    int     `yaml:"min_cells"`
+		MaxCells    int     `yaml:"max_cells"`
+		BEC         struct {
+			VoltageV    float64 `yaml:"voltage_v"`
+			MaxCurrentA float64 `yaml:"max_current_a"`
+		} `yaml:"bec"`
+	} `yaml:"esc"`
+}
+
+// ServoPartFile represents the YAML structure for a hobby servo.
+// Example: parts/motors/sg90.yaml
+type ServoPartFile struct {
+	PartID string `yaml:"part_id"`
+	Type   string `yaml:"type"`
+	Name   string `yaml:"name"`
+	MPN    string `yaml:"mpn"`
+
+	Servo struct {
+		VoltageMinV   float64 `yaml:"voltage_min_v"`
+		VoltageMaxV   float64 `yaml:"voltage_max_v"`
+		IdleCurrentA  float64 `yaml:"idle_current_a"`
+		StallCurrentA float64 `yaml:"stall_current_a"`
+		SignalMinV    float64 `yaml:"signal_min_v"`
+		SignalMaxV    float64 `yaml:"signal_max_v"`
+	} `yaml:"servo"`
+}
+
+// PWMControllerPartFile represents the YAML structure for an I2C PWM controller.
+// Example: parts/drivers/pca9685.yaml
+type PWMControllerPartFile struct {
+	PartID string `yaml:"part_id"`
+	Type   string `yaml:"type"`
+	Name   string `yaml:"name"`
+	MPN    string `yaml:"mpn"`
+
+	PWMController struct {
+		AddressHex       model.I2CAddress         `yaml:"address_hex"`
+		Channels         int                      `yaml:"channels"`
+		LogicVoltageMinV float64                  `yaml:"logic_voltage_min_v"`
+		LogicVoltageMaxV float64                  `yaml:"logic_voltage_max_v"`
+		SupplyCurrent    model.SupplyCurrent      `yaml:"supply_current"`
+		PinCapacitancePF float64                  `yaml:"pin_capacitance_pf"`
+		MaxSpeedKHz      float64                  `yaml:"max_speed_khz"`
+		Addresses        []model.I2CAddressOption `yaml:"addresses"`
+	} `yaml:"pwm_controller"`
+}
+
 // MCUPartFile represents the YAML structure for an MCU.
 // Example: parts/mcus/esp32s3.yaml
 type MCUPartFile struct {
@@ -52,7 +174,19 @@ type MCUPartFile struct {
 	Name   string `yaml:"name"`
 
 	MCU struct {
-		LogicVoltageV float64 `yaml:"logic_voltage_v"`
+		LogicVoltageV float64             `yaml:"logic_voltage_v"`
+		SupplyMinV    float64             `yaml:"supply_min_v"`
+		SupplyMaxV    float64             `yaml:"supply_max_v"`
+		SupplyCurrent model.SupplyCurrent `yaml:"supply_current"`
+		UARTs         []string            `yaml:"uarts"`
+		UARTMaxBaud   int                 `yaml:"uart_max_baud"`
+		FiveVTolerant bool                `yaml:"five_v_tolerant"`
+
+		MaxInterruptRateHz    float64          `yaml:"max_interrupt_rate_hz"`
+		MaxGPIOCurrentmA      float64          `yaml:"max_gpio_current_ma"`
+		MaxTotalGPIOCurrentmA float64          `yaml:"max_total_gpio_current_ma"`
+		Pins                  []model.MCUPin   `yaml:"pins"`
+		IOGroups              []model.IOLevels `yaml:"io_groups"`
 	} `yaml:"mcu"`
 }
 
@@ -65,10 +199,118 @@ type I2CSensorPartFile struct {
 	MPN    string `yaml:"mpn"`
 
 	I2CDevice struct {
-		AddressHex model.I2CAddress `yaml:"address_hex"`
+		AddressHex       model.I2CAddress         `yaml:"address_hex"`
+		SupplyCurrent    model.SupplyCurrent      `yaml:"supply_current"`
+		PinCapacitancePF float64                  `yaml:"pin_capacitance_pf"`
+		MaxSpeedKHz      float64                  `yaml:"max_speed_khz"`
+		Addresses        []model.I2CAddressOption `yaml:"addresses"`
 	} `yaml:"i2c_device"`
 }
 
+// I2CMuxPartFile represents the YAML structure for an I2C multiplexer.
+// Example: parts/interfaces/tca9548a.yaml
+type I2CMuxPartFile struct {
+	PartID string `yaml:"part_id"`
+	Type   string `yaml:"type"`
+	Name   string `yaml:"name"`
+	MPN    string `yaml:"mpn"`
+
+	I2CMux struct {
+		AddressHex       model.I2CAddress         `yaml:"address_hex"`
+		Addresses        []model.I2CAddressOption `yaml:"addresses"`
+		Channels         int                      `yaml:"channels"`
+		SupplyCurrent    model.SupplyCurrent      `yaml:"supply_current"`
+		PinCapacitancePF float64                  `yaml:"pin_capacitance_pf"`
+		MaxSpeedKHz      float64                  `yaml:"max_speed_khz"`
+	} `yaml:"i2c_mux"`
+}
+
+// SPIDevicePartFile represents the YAML structure for an SPI peripheral.
+// Example: parts/sensors/icm42688p.yaml
+type SPIDevicePartFile struct {
+	PartID string `yaml:"part_id"`
+	Type   string `yaml:"type"`
+	Name   string `yaml:"name"`
+	MPN    string `yaml:"mpn"`
+
+	SPIDevice struct {
+		SupportedModes   []int               `yaml:"supported_modes"`
+		MaxClockMHz      float64             `yaml:"max_clock_mhz"`
+		LogicVoltageMinV float64             `yaml:"logic_voltage_min_v"`
+		LogicVoltageMaxV float64             `yaml:"logic_voltage_max_v"`
+		SupplyCurrent    model.SupplyCurrent `yaml:"supply_current"`
+	} `yaml:"spi_device"`
+}
+
+// UARTDevicePartFile represents the YAML structure for a serial peripheral.
+// Example: parts/sensors/neo_m8n.yaml
+type UARTDevicePartFile struct {
+	PartID string `yaml:"part_id"`
+	Type   string `yaml:"type"`
+	Name   string `yaml:"name"`
+	MPN    string `yaml:"mpn"`
+
+	UARTDevice struct {
+		DefaultBaud   int                 `yaml:"default_baud"`
+		SupportedBaud []int               `yaml:"supported_baud"`
+		LogicVoltageV float64             `yaml:"logic_voltage_v"`
+		SupplyCurrent model.SupplyCurrent `yaml:"supply_current"`
+		IO            model.IOLevels      `yaml:"io"`
+	} `yaml:"uart_device"`
+}
+
+// CANNodePartFile represents the YAML structure for a CAN transceiver or CAN device.
+// Example: parts/interfaces/sn65hvd230.yaml
+type CANNodePartFile struct {
+	PartID string `yaml:"part_id"`
+	Type   string `yaml:"type"`
+	Name   string `yaml:"name"`
+	MPN    string `yaml:"mpn"`
+
+	CANNode struct {
+		MaxNodeID             int                 `yaml:"max_node_id"`
+		SupportedBitratesKbps []int               `yaml:"supported_bitrates_kbps"`
+		TerminationOhm        float64             `yaml:"termination_ohm"`
+		TransceiverSupplyMinV float64             `yaml:"transceiver_supply_min_v"`
+		TransceiverSupplyMaxV float64             `yaml:"transceiver_supply_max_v"`
+		SupplyCurrent         model.SupplyCurrent `yaml:"supply_current"`
+	} `yaml:"can_node"`
+}
+
+// LevelShifterPartFile represents the YAML structure for a logic level shifter.
+// Example: parts/interfaces/txs0108e.yaml
+type LevelShifterPartFile struct {
+	PartID string `yaml:"part_id"`
+	Type   string `yaml:"type"`
+	Name   string `yaml:"name"`
+	MPN    string `yaml:"mpn"`
+
+	LevelShifter struct {
+		ASideMinV       float64             `yaml:"a_side_min_v"`
+		ASideMaxV       float64             `yaml:"a_side_max_v"`
+		BSideMinV       float64             `yaml:"b_side_min_v"`
+		BSideMaxV       float64             `yaml:"b_side_max_v"`
+		Direction       string              `yaml:"direction"`
+		MaxDataRateMbps float64             `yaml:"max_data_rate_mbps"`
+		Channels        int                 `yaml:"channels"`
+		SupplyCurrent   model.SupplyCurrent `yaml:"supply_current"`
+	} `yaml:"level_shifter"`
+}
+
+// ConnectorPartFile represents the YAML structure for a power connector.
+// Example: parts/connectors/xt60.yaml
+type ConnectorPartFile struct {
+	PartID string `yaml:"part_id"`
+	Type   string `yaml:"type"`
+	Name   string `yaml:"name"`
+	MPN    string `yaml:"mpn"`
+
+	Connector struct {
+		RatingA     float64 `yaml:"rating_a"`
+		PeakRatingA float64 `yaml:"peak_rating_a"`
+	} `yaml:"connector"`
+}
+
 // Store knows how to load part files from one or more search directories.
 // Earlier directories take precedence over later ones.
 type Store struct{ Dirs []string }
@@ -129,6 +371,78 @@ func (s *Store) LoadMotor(partID string) (MotorPartFile, error) {
 	return part, nil
 }
 
+// LoadStepperMotor loads a stepper motor part by ID, e.g. "motors/nema17_17hs4401".
+func (s *Store) LoadStepperMotor(partID string) (StepperMotorPartFile, error) {
+	var part StepperMotorPartFile
+	if err := s.loadPart(partID, &part); err != nil {
+		return StepperMotorPartFile{}, err
+	}
+	if part.Type != "stepper_motor" {
+		return StepperMotorPartFile{}, fmt.Errorf("expected type stepper_motor, got %q", part.Type)
+	}
+	return part, nil
+}
+
+// LoadStepperDriver loads a stepper driver part by ID, e.g. "drivers/a4988".
+func (s *Store) LoadStepperDriver(partID string) (StepperDriverPartFile, error) {
+	var part StepperDriverPartFile
+	if err := s.loadPart(partID, &part); err != nil {
+		return StepperDriverPartFile{}, err
+	}
+	if part.Type != "stepper_driver" {
+		return StepperDriverPartFile{}, fmt.Errorf("expected type stepper_driver, got %q", part.Type)
+	}
+	return part, nil
+}
+
+// LoadBLDCMotor loads a brushless motor part by ID, e.g. "motors/bldc_2207_1750kv".
+func (s *Store) LoadBLDCMotor(partID string) (BLDCMotorPartFile, error) {
+	var part BLDCMotorPartFile
+	if err := s.loadPart(partID, &part); err != nil {
+		return BLDCMotorPartFile{}, err
+	}
+	if part.Type != "bldc_motor" {
+		return BLDCMotorPartFile{}, fmt.Errorf("expected type bldc_motor, got %q", part.Type)
+	}
+	return part, nil
+}
+
+// LoadESC loads an ESC part by ID, e.g. "drivers/esc_30a_bec".
+func (s *Store) LoadESC(partID string) (ESCPartFile, error) {
+	var part ESCPartFile
+	if err := s.loadPart(partID, &part); err != nil {
+		return ESCPartFile{}, err
+	}
+	if part.Type != "esc" {
+		return ESCPartFile{}, fmt.Errorf("expected type esc, got %q", part.Type)
+	}
+	return part, nil
+}
+
+// LoadServo loads a servo part by ID, e.g. "motors/sg90".
+func (s *Store) LoadServo(partID string) (ServoPartFile, error) {
+	var part ServoPartFile
+	if err := s.loadPart(partID, &part); err != nil {
+		return ServoPartFile{}, err
+	}
+	if part.Type != "servo" {
+		return ServoPartFile{}, fmt.Errorf("expected type servo, got %q", part.Type)
+	}
+	return part, nil
+}
+
+// LoadPWMController loads a PWM controller part by ID, e.g. "drivers/pca9685".
+func (s *Store) LoadPWMController(partID string) (PWMControllerPartFile, error) {
+	var part PWMControllerPartFile
+	if err := s.loadPart(partID, &part); err != nil {
+		return PWMControllerPartFile{}, err
+	}
+	if part.Type != "pwm_controller" {
+		return PWMControllerPartFile{}, fmt.Errorf("expected type pwm_controller, got %q", part.Type)
+	}
+	return part, nil
+}
+
 // LoadMCU loads an MCU part by ID, e.g. "mcus/esp32s3".
 func (s *Store) LoadMCU(partID string) (MCUPartFile, error) {
 	var part MCUPartFile
@@ -153,6 +467,78 @@ func (s *Store) LoadI2CSensor(partID string) (I2CSensorPartFile, error) {
 	return part, nil
 }
 
+// LoadI2CMux loads an I2C multiplexer part by ID, e.g. "interfaces/tca9548a".
+func (s *Store) LoadI2CMux(partID string) (I2CMuxPartFile, error) {
+	var part I2CMuxPartFile
+	if err := s.loadPart(partID, &part); err != nil {
+		return I2CMuxPartFile{}, err
+	}
+	if part.Type != "i2c_mux" {
+		return I2CMuxPartFile{}, fmt.Errorf("expected type i2c_mux, got %q", part.Type)
+	}
+	return part, nil
+}
+
+// LoadSPIDevice loads an SPI peripheral part by ID, e.g. "sensors/icm42688p".
+func (s *Store) LoadSPIDevice(partID string) (SPIDevicePartFile, error) {
+	var part SPIDevicePartFile
+	if err := s.loadPart(partID, &part); err != nil {
+		return SPIDevicePartFile{}, err
+	}
+	if part.Type != "spi_device" {
+		return SPIDevicePartFile{}, fmt.Errorf("expected type spi_device, got %q", part.Type)
+	}
+	return part, nil
+}
+
+// LoadUARTDevice loads a serial peripheral part by ID, e.g. "sensors/neo_m8n".
+func (s *Store) LoadUARTDevice(partID string) (UARTDevicePartFile, error) {
+	var part UARTDevicePartFile
+	if err := s.loadPart(partID, &part); err != nil {
+		return UARTDevicePartFile{}, err
+	}
+	if part.Type != "uart_device" {
+		return UARTDevicePartFile{}, fmt.Errorf("expected type uart_device, got %q", part.Type)
+	}
+	return part, nil
+}
+
+// LoadCANNode loads a CAN node part by ID, e.g. "interfaces/sn65hvd230".
+func (s *Store) LoadCANNode(partID string) (CANNodePartFile, error) {
+	var part CANNodePartFile
+	if err := s.loadPart(partID, &part); err != nil {
+		return CANNodePartFile{}, err
+	}
+	if part.Type != "can_node" {
+		return CANNodePartFile{}, fmt.Errorf("expected type can_node, got %q", part.Type)
+	}
+	return part, nil
+}
+
+// LoadLevelShifter loads a level shifter part by ID, e.g. "interfaces/txs0108e".
+func (s *Store) LoadLevelShifter(partID string) (LevelShifterPartFile, error) {
+	var part LevelShifterPartFile
+	if err := s.loadPart(partID, &part); err != nil {
+		return LevelShifterPartFile{}, err
+	}
+	if part.Type != "level_shifter" {
+		return LevelShifterPartFile{}, fmt.Errorf("expected type level_shifter, got %q", part.Type)
+	}
+	return part, nil
+}
+
+// LoadConnector loads a connector part by ID, e.g. "connectors/xt60".
+func (s *Store) LoadConnector(partID string) (ConnectorPartFile, error) {
+	var part ConnectorPartFile
+	if err := s.loadPart(partID, &part); err != nil {
+		return ConnectorPartFile{}, err
+	}
+	if part.Type != "connector" {
+		return ConnectorPartFile{}, fmt.Errorf("expected type connector, got %q", part.Type)
+	}
+	return part, nil
+}
+
 // loadPart is a small helper to read and unmarshal a YAML file.
 func (s *Store) loadPart(partID string, out any) error {
 	if len(s.Dirs) == 0 {
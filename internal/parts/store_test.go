@@ -46,6 +46,9 @@ func TestStore_LoadDriver_TB6612FNG(t *testing.T) {
 		t.Errorf("expected currents to be >0, got continuous=%.2f, peak=%.2f",
 			drv.MotorDriver.ContinuousPerChA, drv.MotorDriver.PeakPerChA)
 	}
+	if in := drv.MotorDriver.LogicInputs; in.VIHV != 2.0 || in.VILV != 0.8 {
+		t.Errorf("expected TTL input thresholds, got %+v", in)
+	}
 }
 
 func TestStore_LoadMotor_Generic12V(t *testing.T) {
@@ -67,6 +70,37 @@ func TestStore_LoadMotor_Generic12V(t *testing.T) {
 		t.Errorf("expected non-zero currents, got nominal=%.2f, stall=%.2f",
 			m.Motor.NominalCurrentA, m.Motor.StallCurrentA)
 	}
+	if m.Motor.RatedVoltageV != 12 {
+		t.Errorf("expected RatedVoltageV=12, got %.2f", m.Motor.RatedVoltageV)
+	}
+	if m.Motor.NoLoadRPM <= 0 || m.Motor.GearRatio <= 0 {
+		t.Errorf("expected rpm and gear ratio, got %+v", m.Motor)
+	}
+	if enc := m.Motor.Encoder; enc.PPR != 11 || enc.Output != "open_collector" || enc.SupplyMaxV != 5 {
+		t.Errorf("expected hall encoder data, got %+v", enc)
+	}
+}
+
+func TestStore_LoadDriver_BridgeDrop(t *testing.T) {
+	store := NewStore(testPartsDir(t))
+
+	l298, err := store.LoadDriver("drivers/l298")
+	if err != nil {
+		t.Fatalf("LoadDriver(l298) returned error: %v", err)
+	}
+	if l298.MotorDriver.SaturationDropV <= 0 || l298.MotorDriver.SaturationResistanceOhm <= 0 {
+		t.Errorf("expected L298 saturation drop and resistance, got %.2fV, %.2fΩ",
+			l298.MotorDriver.SaturationDropV, l298.MotorDriver.SaturationResistanceOhm)
+	}
+
+	tb, err := store.LoadDriver("drivers/tb6612fng")
+	if err != nil {
+		t.Fatalf("LoadDriver(tb6612fng) returned error: %v", err)
+	}
+	if tb.MotorDriver.RdsOnOhm <= 0 || tb.MotorDriver.SaturationDropV != 0 {
+		t.Errorf("expected TB6612FNG Rds(on) only, got rds=%.2fΩ, sat=%.2fV",
+			tb.MotorDriver.RdsOnOhm, tb.MotorDriver.SaturationDropV)
+	}
 }
 
 func TestStore_LoadMCU_ESP32S3(t *testing.T) {
@@ -86,6 +120,26 @@ func TestStore_LoadMCU_ESP32S3(t *testing.T) {
 	if mcu.MCU.LogicVoltageV <= 0 {
 		t.Errorf("expected non-zero logic voltage, got %.2f", mcu.MCU.LogicVoltageV)
 	}
+	if len(mcu.MCU.UARTs) != 3 || mcu.MCU.FiveVTolerant {
+		t.Errorf("expected three UARTs on 3.3V only pins, got %+v", mcu.MCU)
+	}
+	if len(mcu.MCU.IOGroups) != 1 || mcu.MCU.IOGroups[0].VOHV <= mcu.MCU.IOGroups[0].VIHV {
+		t.Errorf("expected one io group with VOH above VIH, got %+v", mcu.MCU.IOGroups)
+	}
+	if len(mcu.MCU.Pins) == 0 || mcu.MCU.Pins[0].Name != "GPIO0" {
+		t.Fatalf("expected a pin table starting at GPIO0, got %d pins", len(mcu.MCU.Pins))
+	}
+	strapping := 0
+	for _, p := range mcu.MCU.Pins {
+		for _, c := range p.Caps {
+			if c == "strapping" {
+				strapping++
+			}
+		}
+	}
+	if strapping != 4 {
+		t.Errorf("expected 4 strapping pins (GPIO0, 3, 45, 46), got %d", strapping)
+	}
 }
 
 func TestStore_LoadMissingPart_ReturnsError(t *testing.T) {
@@ -179,4 +233,212 @@ func TestStore_LoadI2CSensor_MPU6050(t *testing.T) {
 	if sensor.I2CDevice.AddressHex == 0 {
 		t.Errorf("expected non-zero address, got 0")
 	}
+	if sensor.I2CDevice.MaxSpeedKHz != 400 || sensor.I2CDevice.PinCapacitancePF <= 0 {
+		t.Errorf("expected 400kHz and pin capacitance, got %+v", sensor.I2CDevice)
+	}
+	if len(sensor.I2CDevice.Addresses) != 2 || sensor.I2CDevice.Addresses[1].AddressHex != 0x69 {
+		t.Errorf("expected 0x68 and 0x69 address options, got %+v", sensor.I2CDevice.Addresses)
+	}
+}
+
+func TestStore_LoadI2CMux_TCA9548A(t *testing.T) {
+	store := NewStore(testPartsDir(t))
+
+	mux, err := store.LoadI2CMux("interfaces/tca9548a")
+	if err != nil {
+		t.Fatalf("LoadI2CMux(tca9548a) returned error: %v", err)
+	}
+	if mux.I2CMux.AddressHex != 0x70 || mux.I2CMux.Channels != 8 || len(mux.I2CMux.Addresses) != 8 {
+		t.Errorf("unexpected tca9548a values: %+v", mux.I2CMux)
+	}
+
+	if _, err := store.LoadI2CMux("sensors/vl53l0x"); err == nil {
+		t.Errorf("expected type error loading a sensor as a mux")
+	}
+}
+
+func TestStore_LoadSPIDevices(t *testing.T) {
+	store := NewStore(testPartsDir(t))
+
+	for _, id := range []string{"sensors/icm42688p", "interfaces/mcp2515"} {
+		d, err := store.LoadSPIDevice(id)
+		if err != nil {
+			t.Fatalf("LoadSPIDevice(%s) returned error: %v", id, err)
+		}
+		if d.SPIDevice.MaxClockMHz <= 0 || len(d.SPIDevice.SupportedModes) == 0 {
+			t.Errorf("%s: expected max clock and supported modes, got %+v", id, d.SPIDevice)
+		}
+	}
+
+	if _, err := store.LoadSPIDevice("sensors/mpu6050"); err == nil {
+		t.Errorf("expected type error loading an I2C sensor as an SPI device")
+	}
+}
+
+func TestStore_LoadUARTDevices(t *testing.T) {
+	store := NewStore(testPartsDir(t))
+
+	gps, err := store.LoadUARTDevice("sensors/neo_m8n")
+	if err != nil {
+		t.Fatalf("LoadUARTDevice(neo_m8n) returned error: %v", err)
+	}
+	if gps.UARTDevice.DefaultBaud != 9600 || len(gps.UARTDevice.SupportedBaud) == 0 {
+		t.Errorf("unexpected neo_m8n values: %+v", gps.UARTDevice)
+	}
+
+	if _, err := store.LoadUARTDevice("sensors/icm42688p"); err == nil {
+		t.Errorf("expected type error loading an SPI device as a UART device")
+	}
+}
+
+func TestStore_LoadCANNodes(t *testing.T) {
+	store := NewStore(testPartsDir(t))
+
+	tr, err := store.LoadCANNode("interfaces/mcp2551")
+	if err != nil {
+		t.Fatalf("LoadCANNode(mcp2551) returned error: %v", err)
+	}
+	if tr.CANNode.TransceiverSupplyMinV != 4.5 || len(tr.CANNode.SupportedBitratesKbps) == 0 {
+		t.Errorf("unexpected mcp2551 values: %+v", tr.CANNode)
+	}
+
+	od, err := store.LoadCANNode("drivers/odrive_v3_6")
+	if err != nil {
+		t.Fatalf("LoadCANNode(odrive_v3_6) returned error: %v", err)
+	}
+	if od.CANNode.MaxNodeID != 63 || od.CANNode.TerminationOhm != 0 {
+		t.Errorf("unexpected odrive values: %+v", od.CANNode)
+	}
+
+	if _, err := store.LoadCANNode("drivers/tb6612fng"); err == nil {
+		t.Errorf("expected type error loading a motor driver as a CAN node")
+	}
+}
+
+func TestStore_LoadLevelShifters(t *testing.T) {
+	store := NewStore(testPartsDir(t))
+
+	txs, err := store.LoadLevelShifter("interfaces/txs0108e")
+	if err != nil {
+		t.Fatalf("LoadLevelShifter(txs0108e) returned error: %v", err)
+	}
+	if txs.LevelShifter.ASideMaxV != 3.6 || txs.LevelShifter.BSideMaxV != 5.5 || txs.LevelShifter.Channels != 8 {
+		t.Errorf("unexpected txs0108e values: %+v", txs.LevelShifter)
+	}
+
+	buf, err := store.LoadLevelShifter("interfaces/74ahct125")
+	if err != nil {
+		t.Fatalf("LoadLevelShifter(74ahct125) returned error: %v", err)
+	}
+	if buf.LevelShifter.Direction != "a_to_b" || buf.LevelShifter.MaxDataRateMbps <= 0 {
+		t.Errorf("unexpected 74ahct125 values: %+v", buf.LevelShifter)
+	}
+
+	if _, err := store.LoadLevelShifter("interfaces/sn65hvd230"); err == nil {
+		t.Errorf("expected type error loading a CAN transceiver as a level shifter")
+	}
+}
+
+func TestStore_LoadStepperParts(t *testing.T) {
+	store := NewStore(testPartsDir(t))
+
+	for _, id := range []string{"drivers/a4988", "drivers/drv8825", "drivers/tmc2209"} {
+		drv, err := store.LoadStepperDriver(id)
+		if err != nil {
+			t.Fatalf("LoadStepperDriver(%s) returned error: %v", id, err)
+		}
+		if drv.StepperDriver.MaxPhaseCurrentA <= 0 {
+			t.Errorf("%s: expected max_phase_current_a > 0", id)
+		}
+		if len(drv.StepperDriver.SupportedMicrosteps) == 0 {
+			t.Errorf("%s: expected supported_microsteps to be set", id)
+		}
+	}
+
+	m, err := store.LoadStepperMotor("motors/nema17_17hs4401")
+	if err != nil {
+		t.Fatalf("LoadStepperMotor(nema17_17hs4401) returned error: %v", err)
+	}
+	if m.StepperMotor.PhaseCurrentA != 1.7 || m.StepperMotor.StepAngleDeg != 1.8 {
+		t.Errorf("unexpected stepper motor values: %+v", m.StepperMotor)
+	}
+
+	if _, err := store.LoadStepperDriver("drivers/tb6612fng"); err == nil {
+		t.Errorf("expected type error loading a DC driver as a stepper driver")
+	}
+}
+
+func TestStore_LoadBLDCParts(t *testing.T) {
+	store := NewStore(testPartsDir(t))
+
+	m, err := store.LoadBLDCMotor("motors/bldc_2207_1750kv")
+	if err != nil {
+		t.Fatalf("LoadBLDCMotor(bldc_2207_1750kv) returned error: %v", err)
+	}
+	if m.BLDCMotor.KV != 1750 || m.BLDCMotor.MinCells != 4 || m.BLDCMotor.MaxCells != 6 {
+		t.Errorf("unexpected bldc motor values: %+v", m.BLDCMotor)
+	}
+
+	esc, err := store.LoadESC("drivers/esc_30a_bec")
+	if err != nil {
+		t.Fatalf("LoadESC(esc_30a_bec) returned error: %v", err)
+	}
+	if esc.ESC.BurstA <= esc.ESC.ContinuousA {
+		t.Errorf("expected burst above continuous, got %+v", esc.ESC)
+	}
+	if esc.ESC.BEC.VoltageV != 5 {
+		t.Errorf("expected 5V BEC, got %.2f", esc.ESC.BEC.VoltageV)
+	}
+
+	if _, err := store.LoadESC("motors/bldc_2207_1750kv"); err == nil {
+		t.Errorf("expected type error loading a motor as an ESC")
+	}
+}
+
+func TestStore_LoadServoParts(t *testing.T) {
+	store := NewStore(testPartsDir(t))
+
+	for _, id := range []string{"motors/sg90", "motors/mg996r"} {
+		sv, err := store.LoadServo(id)
+		if err != nil {
+			t.Fatalf("LoadServo(%s) returned error: %v", id, err)
+		}
+		if sv.Servo.StallCurrentA <= sv.Servo.IdleCurrentA {
+			t.Errorf("%s: expected stall above idle current, got %+v", id, sv.Servo)
+		}
+	}
+
+	c, err := store.LoadPWMController("drivers/pca9685")
+	if err != nil {
+		t.Fatalf("LoadPWMController(pca9685) returned error: %v", err)
+	}
+	if c.PWMController.AddressHex != 0x40 || c.PWMController.Channels != 16 {
+		t.Errorf("unexpected pca9685 values: %+v", c.PWMController)
+	}
+}
+
+func TestStore_LoadConnectors(t *testing.T) {
+	store := NewStore(testPartsDir(t))
+
+	xt60, err := store.LoadConnector("connectors/xt60")
+	if err != nil {
+		t.Fatalf("LoadConnector(xt60) returned error: %v", err)
+	}
+	if xt60.Connector.RatingA != 30 || xt60.Connector.PeakRatingA != 60 {
+		t.Errorf("unexpected xt60 values: %+v", xt60.Connector)
+	}
+
+	for _, id := range []string{"connectors/xt30", "connectors/jst_xh", "connectors/dupont"} {
+		c, err := store.LoadConnector(id)
+		if err != nil {
+			t.Fatalf("LoadConnector(%s) returned error: %v", id, err)
+		}
+		if c.Connector.RatingA <= 0 {
+			t.Errorf("%s: expected rating_a > 0", id)
+		}
+	}
+
+	if _, err := store.LoadConnector("interfaces/txs0108e"); err == nil {
+		t.Errorf("expected type error loading a level shifter as a connector")
+	}
 }
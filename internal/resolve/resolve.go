@@ -2,6 +2,7 @@ package resolve
 
 import (
 	"fmt"
+	"reflect"
 
 	"github.com/badimirzai/robotics-verifier-cli/internal/model"
 	"github.com/badimirzai/robotics-verifier-cli/internal/parts"
@@ -19,12 +20,28 @@ func ResolveAll(spec model.RobotSpec, store *parts.Store) (model.RobotSpec, erro
 	}
 	resolved.MCU = mcu
 
-	// Motor driver
-	drv, err := resolveDriver(spec.Driver, store)
-	if err != nil {
-		return model.RobotSpec{}, err
+	// Motor driver. The singular key is optional once motor_drivers is used, and
+	// when the spec has no DC motors at all (e.g. a stepper-only arm).
+	if !reflect.ValueOf(spec.Driver).IsZero() || (len(spec.Drivers) == 0 && len(spec.Motors) > 0) {
+		drv, err := resolveDriver(spec.Driver, store)
+		if err != nil {
+			return model.RobotSpec{}, fmt.Errorf("motor_driver: %w", err)
+		}
+		resolved.Driver = drv
+	}
+
+	// Motor drivers slice
+	if len(spec.Drivers) > 0 {
+		drivers := make([]model.MotorDriver, len(spec.Drivers))
+		for i, d := range spec.Drivers {
+			rd, err := resolveDriver(d, store)
+			if err != nil {
+				return model.RobotSpec{}, fmt.Errorf("motor_drivers[%d]: %w", i, err)
+			}
+			drivers[i] = rd
+		}
+		resolved.Drivers = drivers
 	}
-	resolved.Driver = drv
 
 	// Motors slice
 	motors := make([]model.Motor, len(spec.Motors))
@@ -37,6 +54,50 @@ func ResolveAll(spec model.RobotSpec, store *parts.Store) (model.RobotSpec, erro
 	}
 	resolved.Motors = motors
 
+	// Steppers
+	steppers := make([]model.Stepper, len(spec.Steppers))
+	for i, st := range spec.Steppers {
+		rs, err := resolveStepper(st, store)
+		if err != nil {
+			return model.RobotSpec{}, fmt.Errorf("steppers[%d]: %w", i, err)
+		}
+		steppers[i] = rs
+	}
+	resolved.Steppers = steppers
+
+	// Brushless drives
+	bldc := make([]model.BLDC, len(spec.BLDC))
+	for i, b := range spec.BLDC {
+		rb, err := resolveBLDC(b, store)
+		if err != nil {
+			return model.RobotSpec{}, fmt.Errorf("bldc[%d]: %w", i, err)
+		}
+		bldc[i] = rb
+	}
+	resolved.BLDC = bldc
+
+	// Servos
+	servos := make([]model.Servo, len(spec.Servos))
+	for i, sv := range spec.Servos {
+		rs, err := resolveServo(sv, store)
+		if err != nil {
+			return model.RobotSpec{}, fmt.Errorf("servos[%d]: %w", i, err)
+		}
+		servos[i] = rs
+	}
+	resolved.Servos = servos
+
+	// PWM controllers
+	pwm := make([]model.PWMController, len(spec.PWM))
+	for i, c := range spec.PWM {
+		rc, err := resolvePWMController(c, store)
+		if err != nil {
+			return model.RobotSpec{}, fmt.Errorf("pwm_controllers[%d]: %w", i, err)
+		}
+		pwm[i] = rc
+	}
+	resolved.PWM = pwm
+
 	// I2C buses
 	buses := make([]model.I2CBus, len(spec.I2CBuses))
 	for i, bus := range spec.I2CBuses {
@@ -48,6 +109,68 @@ func ResolveAll(spec model.RobotSpec, store *parts.Store) (model.RobotSpec, erro
 	}
 	resolved.I2CBuses = buses
 
+	// SPI buses
+	spiBuses := make([]model.SPIBus, len(spec.SPIBuses))
+	for i, bus := range spec.SPIBuses {
+		rb, err := resolveSPIBus(bus, store)
+		if err != nil {
+			return model.RobotSpec{}, fmt.Errorf("spi_buses[%d]: %w", i, err)
+		}
+		spiBuses[i] = rb
+	}
+	resolved.SPIBuses = spiBuses
+
+	// UART peripherals
+	uarts := make([]model.UART, len(spec.UARTs))
+	for i, u := range spec.UARTs {
+		ru, err := resolveUART(u, store)
+		if err != nil {
+			return model.RobotSpec{}, fmt.Errorf("uarts[%d]: %w", i, err)
+		}
+		uarts[i] = ru
+	}
+	resolved.UARTs = uarts
+
+	// CAN buses
+	canBuses := make([]model.CANBus, len(spec.CANBuses))
+	for i, bus := range spec.CANBuses {
+		rb, err := resolveCANBus(bus, store)
+		if err != nil {
+			return model.RobotSpec{}, fmt.Errorf("can_buses[%d]: %w", i, err)
+		}
+		canBuses[i] = rb
+	}
+	resolved.CANBuses = canBuses
+
+	// Encoders take their defaults from the resolved motor they sit on.
+	encoders := make([]model.Encoder, len(spec.Encoders))
+	for i, e := range spec.Encoders {
+		encoders[i] = resolveEncoder(e, resolved.Motors)
+	}
+	resolved.Encoders = encoders
+
+	// Level shifters
+	shifters := make([]model.LevelShifter, len(spec.Shifters))
+	for i, ls := range spec.Shifters {
+		rs, err := resolveLevelShifter(ls, store)
+		if err != nil {
+			return model.RobotSpec{}, fmt.Errorf("level_shifters[%d]: %w", i, err)
+		}
+		shifters[i] = rs
+	}
+	resolved.Shifters = shifters
+
+	// Harness segments
+	harness := make([]model.HarnessSegment, len(spec.Harness))
+	for i, seg := range spec.Harness {
+		rs, err := resolveHarnessSegment(seg, store)
+		if err != nil {
+			return model.RobotSpec{}, fmt.Errorf("harness[%d]: %w", i, err)
+		}
+		harness[i] = rs
+	}
+	resolved.Harness = harness
+
 	return resolved, nil
 }
 
@@ -63,6 +186,38 @@ func resolveMCU(in model.MCU, store *parts.Store) (model.MCU, error) {
 		if out.LogicVoltageV == 0 {
 			out.LogicVoltageV = p.MCU.LogicVoltageV
 		}
+		if out.SupplyMinV == 0 {
+			out.SupplyMinV = p.MCU.SupplyMinV
+		}
+		if out.SupplyMaxV == 0 {
+			out.SupplyMaxV = p.MCU.SupplyMaxV
+		}
+		out.SupplyCurrent = mergeSupplyCurrent(out.SupplyCurrent, p.MCU.SupplyCurrent)
+		if len(out.UARTs) == 0 {
+			out.UARTs = p.MCU.UARTs
+		}
+		if out.UARTMaxBaud == 0 {
+			out.UARTMaxBaud = p.MCU.UARTMaxBaud
+		}
+		if out.FiveVTolerant == nil && p.MCU.FiveVTolerant {
+			tolerant := true
+			out.FiveVTolerant = &tolerant
+		}
+		if out.MaxGPIOCurrentmA == 0 {
+			out.MaxGPIOCurrentmA = p.MCU.MaxGPIOCurrentmA
+		}
+		if out.MaxTotalGPIOCurrentmA == 0 {
+			out.MaxTotalGPIOCurrentmA = p.MCU.MaxTotalGPIOCurrentmA
+		}
+		if len(out.Pins) == 0 {
+			out.Pins = p.MCU.Pins
+		}
+		if out.MaxInterruptRateHz == 0 {
+			out.MaxInterruptRateHz = p.MCU.MaxInterruptRateHz
+		}
+		if len(out.IOGroups) == 0 {
+			out.IOGroups = p.MCU.IOGroups
+		}
 		if out.Name == "" {
 			out.Name = p.Name
 		}
@@ -75,6 +230,8 @@ func resolveMCU(in model.MCU, store *parts.Store) (model.MCU, error) {
 	return out, nil
 }
 
+// resolveDriver merges a driver's part defaults. Errors name fields relative to the
+// driver; the caller prefixes motor_driver or motor_drivers[i].
 func resolveDriver(in model.MotorDriver, store *parts.Store) (model.MotorDriver, error) {
 	out := in
 
@@ -105,6 +262,31 @@ func resolveDriver(in model.MotorDriver, store *parts.Store) (model.MotorDriver,
 		if out.PeakPerChA == 0 {
 			out.PeakPerChA = p.MotorDriver.PeakPerChA
 		}
+		out.LogicCurrent = mergeSupplyCurrent(out.LogicCurrent, p.MotorDriver.LogicCurrent)
+		if out.LogicInputs == (model.IOLevels{}) {
+			out.LogicInputs = p.MotorDriver.LogicInputs
+		}
+		if out.ParallelDerating == 0 {
+			out.ParallelDerating = p.MotorDriver.ParallelDerating
+		}
+		if out.RdsOnOhm == 0 {
+			out.RdsOnOhm = p.MotorDriver.RdsOnOhm
+		}
+		if out.SaturationDropV == 0 {
+			out.SaturationDropV = p.MotorDriver.SaturationDropV
+		}
+		if out.SaturationResistanceOhm == 0 {
+			out.SaturationResistanceOhm = p.MotorDriver.SaturationResistanceOhm
+		}
+		if out.ThetaJACPerW == 0 {
+			out.ThetaJACPerW = p.MotorDriver.ThetaJACPerW
+		}
+		if out.TjMaxC == 0 {
+			out.TjMaxC = p.MotorDriver.TjMaxC
+		}
+		if out.ThetaJAHeatsinkCPerW == 0 {
+			out.ThetaJAHeatsinkCPerW = p.MotorDriver.ThetaJAHeatsinkCPerW
+		}
 		if out.Name == "" {
 			out.Name = p.Name
 		}
@@ -112,16 +294,16 @@ func resolveDriver(in model.MotorDriver, store *parts.Store) (model.MotorDriver,
 
 	// Sanity checks after merging
 	if out.Channels <= 0 {
-		return model.MotorDriver{}, fmt.Errorf("motor_driver.channels must be > 0 after resolving")
+		return model.MotorDriver{}, fmt.Errorf("channels must be > 0 after resolving")
 	}
 	if out.MotorSupplyMinV == 0 || out.MotorSupplyMaxV == 0 {
-		return model.MotorDriver{}, fmt.Errorf("motor_driver.motor_supply_min_v and motor_driver.motor_supply_max_v missing after resolving")
+		return model.MotorDriver{}, fmt.Errorf("motor_supply_min_v and motor_supply_max_v missing after resolving")
 	}
 	if out.LogicVoltageMinV == 0 || out.LogicVoltageMaxV == 0 {
-		return model.MotorDriver{}, fmt.Errorf("motor_driver.logic_voltage_min_v and motor_driver.logic_voltage_max_v missing after resolving")
+		return model.MotorDriver{}, fmt.Errorf("logic_voltage_min_v and logic_voltage_max_v missing after resolving")
 	}
 	if out.PeakPerChA == 0 {
-		return model.MotorDriver{}, fmt.Errorf("motor_driver.peak_per_channel_a missing after resolving")
+		return model.MotorDriver{}, fmt.Errorf("peak_per_channel_a missing after resolving")
 	}
 
 	return out, nil
@@ -148,6 +330,16 @@ func resolveMotor(in model.Motor, store *parts.Store) (model.Motor, error) {
 		if out.StallCurrentA == 0 {
 			out.StallCurrentA = p.Motor.StallCurrentA
 		}
+		if out.RatedVoltageV == 0 {
+			out.RatedVoltageV = p.Motor.RatedVoltageV
+		}
+		if out.NoLoadRPM == 0 {
+			out.NoLoadRPM = p.Motor.NoLoadRPM
+		}
+		if out.GearRatio == 0 {
+			out.GearRatio = p.Motor.GearRatio
+		}
+		out.Encoder = mergeMotorEncoder(out.Encoder, p.Motor.Encoder)
 		if out.Name == "" {
 			out.Name = p.Name
 		}
@@ -163,6 +355,207 @@ func resolveMotor(in model.Motor, store *parts.Store) (model.Motor, error) {
 	return out, nil
 }
 
+func resolveStepper(in model.Stepper, store *parts.Store) (model.Stepper, error) {
+	out := in
+
+	if in.Motor.Part != "" {
+		p, err := store.LoadStepperMotor(in.Motor.Part)
+		if err != nil {
+			return model.Stepper{}, fmt.Errorf("load stepper motor part %q: %w", in.Motor.Part, err)
+		}
+		m := &out.Motor
+		if m.PhaseCurrentA == 0 {
+			m.PhaseCurrentA = p.StepperMotor.PhaseCurrentA
+		}
+		if m.PhaseResistanceOhm == 0 {
+			m.PhaseResistanceOhm = p.StepperMotor.PhaseResistanceOhm
+		}
+		if m.RatedVoltageV == 0 {
+			m.RatedVoltageV = p.StepperMotor.RatedVoltageV
+		}
+		if m.StepAngleDeg == 0 {
+			m.StepAngleDeg = p.StepperMotor.StepAngleDeg
+		}
+		if m.Name == "" {
+			m.Name = p.Name
+		}
+	}
+
+	if in.Driver.Part != "" {
+		p, err := store.LoadStepperDriver(in.Driver.Part)
+		if err != nil {
+			return model.Stepper{}, fmt.Errorf("load stepper driver part %q: %w", in.Driver.Part, err)
+		}
+		d := &out.Driver
+		if d.MotorSupplyMinV == 0 {
+			d.MotorSupplyMinV = p.StepperDriver.MotorSupplyMinV
+		}
+		if d.MotorSupplyMaxV == 0 {
+			d.MotorSupplyMaxV = p.StepperDriver.MotorSupplyMaxV
+		}
+		if d.MaxPhaseCurrentA == 0 {
+			d.MaxPhaseCurrentA = p.StepperDriver.MaxPhaseCurrentA
+		}
+		if len(d.SupportedMicrosteps) == 0 {
+			d.SupportedMicrosteps = p.StepperDriver.SupportedMicrosteps
+		}
+		if d.LogicVoltageMinV == 0 {
+			d.LogicVoltageMinV = p.StepperDriver.LogicVoltageMinV
+		}
+		if d.LogicVoltageMaxV == 0 {
+			d.LogicVoltageMaxV = p.StepperDriver.LogicVoltageMaxV
+		}
+		d.LogicCurrent = mergeSupplyCurrent(d.LogicCurrent, p.StepperDriver.LogicCurrent)
+		if d.Name == "" {
+			d.Name = p.Name
+		}
+	}
+
+	if out.Count <= 0 {
+		return model.Stepper{}, fmt.Errorf("steppers[].count must be > 0")
+	}
+
+	return out, nil
+}
+
+func resolveBLDC(in model.BLDC, store *parts.Store) (model.BLDC, error) {
+	out := in
+
+	if in.Motor.Part != "" {
+		p, err := store.LoadBLDCMotor(in.Motor.Part)
+		if err != nil {
+			return model.BLDC{}, fmt.Errorf("load bldc motor part %q: %w", in.Motor.Part, err)
+		}
+		m := &out.Motor
+		if m.KV == 0 {
+			m.KV = p.BLDCMotor.KV
+		}
+		if m.MaxCurrentA == 0 {
+			m.MaxCurrentA = p.BLDCMotor.MaxCurrentA
+		}
+		if m.MinCells == 0 {
+			m.MinCells = p.BLDCMotor.MinCells
+		}
+		if m.MaxCells == 0 {
+			m.MaxCells = p.BLDCMotor.MaxCells
+		}
+		if m.Name == "" {
+			m.Name = p.Name
+		}
+	}
+
+	if in.ESC.Part != "" {
+		p, err := store.LoadESC(in.ESC.Part)
+		if err != nil {
+			return model.BLDC{}, fmt.Errorf("load esc part %q: %w", in.ESC.Part, err)
+		}
+		e := &out.ESC
+		if e.ContinuousA == 0 {
+			e.ContinuousA = p.ESC.ContinuousA
+		}
+		if e.BurstA == 0 {
+			e.BurstA = p.ESC.BurstA
+		}
+		if e.MinCells == 0 {
+			e.MinCells = p.ESC.MinCells
+		}
+		if e.MaxCells == 0 {
+			e.MaxCells = p.ESC.MaxCells
+		}
+		if e.BEC.VoltageV == 0 {
+			e.BEC.VoltageV = p.ESC.BEC.VoltageV
+		}
+		if e.BEC.MaxCurrentA == 0 {
+			e.BEC.MaxCurrentA = p.ESC.BEC.MaxCurrentA
+		}
+		if e.Name == "" {
+			e.Name = p.Name
+		}
+	}
+
+	if out.Count <= 0 {
+		return model.BLDC{}, fmt.Errorf("bldc[].count must be > 0")
+	}
+
+	return out, nil
+}
+
+func resolveServo(in model.Servo, store *parts.Store) (model.Servo, error) {
+	out := in
+
+	if in.Part != "" {
+		p, err := store.LoadServo(in.Part)
+		if err != nil {
+			return model.Servo{}, fmt.Errorf("load servo part %q: %w", in.Part, err)
+		}
+		if out.VoltageMinV == 0 {
+			out.VoltageMinV = p.Servo.VoltageMinV
+		}
+		if out.VoltageMaxV == 0 {
+			out.VoltageMaxV = p.Servo.VoltageMaxV
+		}
+		if out.IdleCurrentA == 0 {
+			out.IdleCurrentA = p.Servo.IdleCurrentA
+		}
+		if out.StallCurrentA == 0 {
+			out.StallCurrentA = p.Servo.StallCurrentA
+		}
+		if out.SignalMinV == 0 {
+			out.SignalMinV = p.Servo.SignalMinV
+		}
+		if out.SignalMaxV == 0 {
+			out.SignalMaxV = p.Servo.SignalMaxV
+		}
+		if out.Name == "" {
+			out.Name = p.Name
+		}
+	}
+
+	if out.Count <= 0 {
+		return model.Servo{}, fmt.Errorf("servos[].count must be > 0")
+	}
+
+	return out, nil
+}
+
+func resolvePWMController(in model.PWMController, store *parts.Store) (model.PWMController, error) {
+	out := in
+
+	if in.Part != "" {
+		p, err := store.LoadPWMController(in.Part)
+		if err != nil {
+			return model.PWMController{}, fmt.Errorf("load pwm controller part %q: %w", in.Part, err)
+		}
+		if out.AddressHex == 0 {
+			out.AddressHex = p.PWMController.AddressHex
+		}
+		if out.Channels == 0 {
+			out.Channels = p.PWMController.Channels
+		}
+		if out.LogicVoltageMinV == 0 {
+			out.LogicVoltageMinV = p.PWMController.LogicVoltageMinV
+		}
+		if out.LogicVoltageMaxV == 0 {
+			out.LogicVoltageMaxV = p.PWMController.LogicVoltageMaxV
+		}
+		out.SupplyCurrent = mergeSupplyCurrent(out.SupplyCurrent, p.PWMController.SupplyCurrent)
+		if out.PinCapacitancePF == 0 {
+			out.PinCapacitancePF = p.PWMController.PinCapacitancePF
+		}
+		if out.MaxSpeedKHz == 0 {
+			out.MaxSpeedKHz = p.PWMController.MaxSpeedKHz
+		}
+		if len(out.Addresses) == 0 {
+			out.Addresses = p.PWMController.Addresses
+		}
+		if out.Name == "" {
+			out.Name = p.Name
+		}
+	}
+
+	return out, nil
+}
+
 func resolveI2CBus(in model.I2CBus, store *parts.Store) (model.I2CBus, error) {
 	out := in
 	devices := make([]model.I2CDevice, len(in.Devices))
@@ -178,6 +571,9 @@ func resolveI2CBus(in model.I2CBus, store *parts.Store) (model.I2CBus, error) {
 }
 
 func resolveI2CDevice(in model.I2CDevice, store *parts.Store) (model.I2CDevice, error) {
+	if len(in.Segments) > 0 {
+		return resolveI2CMux(in, store)
+	}
 	out := in
 
 	if in.Part != "" {
@@ -191,7 +587,299 @@ func resolveI2CDevice(in model.I2CDevice, store *parts.Store) (model.I2CDevice,
 		if out.AddressHex == 0 {
 			out.AddressHex = p.I2CDevice.AddressHex
 		}
+		out.SupplyCurrent = mergeSupplyCurrent(out.SupplyCurrent, p.I2CDevice.SupplyCurrent)
+		if out.PinCapacitancePF == 0 {
+			out.PinCapacitancePF = p.I2CDevice.PinCapacitancePF
+		}
+		if out.MaxSpeedKHz == 0 {
+			out.MaxSpeedKHz = p.I2CDevice.MaxSpeedKHz
+		}
+		if len(out.Addresses) == 0 {
+			out.Addresses = p.I2CDevice.Addresses
+		}
+	}
+
+	return out, nil
+}
+
+// resolveI2CMux resolves a multiplexer and every device on its segments.
+func resolveI2CMux(in model.I2CDevice, store *parts.Store) (model.I2CDevice, error) {
+	out := in
+
+	if in.Part != "" {
+		p, err := store.LoadI2CMux(in.Part)
+		if err != nil {
+			return model.I2CDevice{}, fmt.Errorf("load i2c mux part %q: %w", in.Part, err)
+		}
+		if out.Name == "" {
+			out.Name = p.Name
+		}
+		if out.AddressHex == 0 {
+			out.AddressHex = p.I2CMux.AddressHex
+		}
+		if len(out.Addresses) == 0 {
+			out.Addresses = p.I2CMux.Addresses
+		}
+		if out.MuxChannels == 0 {
+			out.MuxChannels = p.I2CMux.Channels
+		}
+		out.SupplyCurrent = mergeSupplyCurrent(out.SupplyCurrent, p.I2CMux.SupplyCurrent)
+		if out.PinCapacitancePF == 0 {
+			out.PinCapacitancePF = p.I2CMux.PinCapacitancePF
+		}
+		if out.MaxSpeedKHz == 0 {
+			out.MaxSpeedKHz = p.I2CMux.MaxSpeedKHz
+		}
+	}
+
+	segments := make([]model.I2CSegment, len(in.Segments))
+	for i, seg := range in.Segments {
+		devices := make([]model.I2CDevice, len(seg.Devices))
+		for j, d := range seg.Devices {
+			rd, err := resolveI2CDevice(d, store)
+			if err != nil {
+				return model.I2CDevice{}, fmt.Errorf("segments[%d].devices[%d]: %w", i, j, err)
+			}
+			devices[j] = rd
+		}
+		segments[i] = model.I2CSegment{Channel: seg.Channel, Devices: devices}
+	}
+	out.Segments = segments
+	return out, nil
+}
+
+func resolveSPIBus(in model.SPIBus, store *parts.Store) (model.SPIBus, error) {
+	out := in
+	devices := make([]model.SPIDevice, len(in.Devices))
+	for i, d := range in.Devices {
+		rd, err := resolveSPIDevice(d, store)
+		if err != nil {
+			return model.SPIBus{}, fmt.Errorf("devices[%d]: %w", i, err)
+		}
+		devices[i] = rd
+	}
+	out.Devices = devices
+	return out, nil
+}
+
+func resolveSPIDevice(in model.SPIDevice, store *parts.Store) (model.SPIDevice, error) {
+	out := in
+
+	if in.Part != "" {
+		p, err := store.LoadSPIDevice(in.Part)
+		if err != nil {
+			return model.SPIDevice{}, fmt.Errorf("load spi device part %q: %w", in.Part, err)
+		}
+		if out.Name == "" {
+			out.Name = p.Name
+		}
+		if len(out.SupportedModes) == 0 {
+			out.SupportedModes = p.SPIDevice.SupportedModes
+		}
+		if out.MaxClockMHz == 0 {
+			out.MaxClockMHz = p.SPIDevice.MaxClockMHz
+		}
+		if out.LogicVoltageMinV == 0 {
+			out.LogicVoltageMinV = p.SPIDevice.LogicVoltageMinV
+		}
+		if out.LogicVoltageMaxV == 0 {
+			out.LogicVoltageMaxV = p.SPIDevice.LogicVoltageMaxV
+		}
+		out.SupplyCurrent = mergeSupplyCurrent(out.SupplyCurrent, p.SPIDevice.SupplyCurrent)
+	}
+
+	return out, nil
+}
+
+func resolveUART(in model.UART, store *parts.Store) (model.UART, error) {
+	out := in
+
+	if in.Part != "" {
+		p, err := store.LoadUARTDevice(in.Part)
+		if err != nil {
+			return model.UART{}, fmt.Errorf("load uart device part %q: %w", in.Part, err)
+		}
+		if out.Name == "" {
+			out.Name = p.Name
+		}
+		if out.Baud == 0 {
+			out.Baud = p.UARTDevice.DefaultBaud
+		}
+		if len(out.SupportedBaud) == 0 {
+			out.SupportedBaud = p.UARTDevice.SupportedBaud
+		}
+		if out.LogicVoltageV == 0 {
+			out.LogicVoltageV = p.UARTDevice.LogicVoltageV
+		}
+		out.SupplyCurrent = mergeSupplyCurrent(out.SupplyCurrent, p.UARTDevice.SupplyCurrent)
+		if out.IO == (model.IOLevels{}) {
+			out.IO = p.UARTDevice.IO
+		}
+	}
+
+	return out, nil
+}
+
+func resolveCANBus(in model.CANBus, store *parts.Store) (model.CANBus, error) {
+	out := in
+	nodes := make([]model.CANNode, len(in.Nodes))
+	for i, n := range in.Nodes {
+		rn, err := resolveCANNode(n, store)
+		if err != nil {
+			return model.CANBus{}, fmt.Errorf("nodes[%d]: %w", i, err)
+		}
+		nodes[i] = rn
+	}
+	out.Nodes = nodes
+	return out, nil
+}
+
+func resolveCANNode(in model.CANNode, store *parts.Store) (model.CANNode, error) {
+	out := in
+
+	if in.Part != "" {
+		p, err := store.LoadCANNode(in.Part)
+		if err != nil {
+			return model.CANNode{}, fmt.Errorf("load can node part %q: %w", in.Part, err)
+		}
+		if out.Name == "" {
+			out.Name = p.Name
+		}
+		if out.MaxNodeID == 0 {
+			out.MaxNodeID = p.CANNode.MaxNodeID
+		}
+		if len(out.SupportedBitratesKbps) == 0 {
+			out.SupportedBitratesKbps = p.CANNode.SupportedBitratesKbps
+		}
+		if out.TerminationOhm == 0 {
+			out.TerminationOhm = p.CANNode.TerminationOhm
+		}
+		if out.TransceiverSupplyMinV == 0 {
+			out.TransceiverSupplyMinV = p.CANNode.TransceiverSupplyMinV
+		}
+		if out.TransceiverSupplyMaxV == 0 {
+			out.TransceiverSupplyMaxV = p.CANNode.TransceiverSupplyMaxV
+		}
+		out.SupplyCurrent = mergeSupplyCurrent(out.SupplyCurrent, p.CANNode.SupplyCurrent)
+	}
+
+	return out, nil
+}
+
+func resolveLevelShifter(in model.LevelShifter, store *parts.Store) (model.LevelShifter, error) {
+	out := in
+
+	if in.Part != "" {
+		p, err := store.LoadLevelShifter(in.Part)
+		if err != nil {
+			return model.LevelShifter{}, fmt.Errorf("load level shifter part %q: %w", in.Part, err)
+		}
+		if out.Name == "" {
+			out.Name = p.Name
+		}
+		if out.ASideMinV == 0 {
+			out.ASideMinV = p.LevelShifter.ASideMinV
+		}
+		if out.ASideMaxV == 0 {
+			out.ASideMaxV = p.LevelShifter.ASideMaxV
+		}
+		if out.BSideMinV == 0 {
+			out.BSideMinV = p.LevelShifter.BSideMinV
+		}
+		if out.BSideMaxV == 0 {
+			out.BSideMaxV = p.LevelShifter.BSideMaxV
+		}
+		if out.Direction == "" {
+			out.Direction = p.LevelShifter.Direction
+		}
+		if out.MaxDataRateMbps == 0 {
+			out.MaxDataRateMbps = p.LevelShifter.MaxDataRateMbps
+		}
+		if out.Channels == 0 {
+			out.Channels = p.LevelShifter.Channels
+		}
+		out.SupplyCurrent = mergeSupplyCurrent(out.SupplyCurrent, p.LevelShifter.SupplyCurrent)
 	}
 
 	return out, nil
 }
+
+func resolveHarnessSegment(in model.HarnessSegment, store *parts.Store) (model.HarnessSegment, error) {
+	out := in
+	out.Connectors = make([]model.Connector, len(in.Connectors))
+	for i, c := range in.Connectors {
+		rc, err := resolveConnector(c, store)
+		if err != nil {
+			return model.HarnessSegment{}, fmt.Errorf("connectors[%d]: %w", i, err)
+		}
+		out.Connectors[i] = rc
+	}
+	return out, nil
+}
+
+func resolveConnector(in model.Connector, store *parts.Store) (model.Connector, error) {
+	out := in
+
+	if in.Part != "" {
+		p, err := store.LoadConnector(in.Part)
+		if err != nil {
+			return model.Connector{}, fmt.Errorf("load connector part %q: %w", in.Part, err)
+		}
+		if out.Name == "" {
+			out.Name = p.Name
+		}
+		if out.RatingA == 0 {
+			out.RatingA = p.Connector.RatingA
+		}
+		if out.PeakRatingA == 0 {
+			out.PeakRatingA = p.Connector.PeakRatingA
+		}
+	}
+
+	return out, nil
+}
+
+func resolveEncoder(in model.Encoder, motors []model.Motor) model.Encoder {
+	out := in
+	for _, m := range motors {
+		if in.Motor != "" && m.Name == in.Motor {
+			out.MotorEncoder = mergeMotorEncoder(out.MotorEncoder, m.Encoder)
+			break
+		}
+	}
+	return out
+}
+
+// mergeMotorEncoder fills unset encoder fields from part defaults.
+func mergeMotorEncoder(in, part model.MotorEncoder) model.MotorEncoder {
+	out := in
+	if out.PPR == 0 {
+		out.PPR = part.PPR
+	}
+	if out.SupplyMinV == 0 {
+		out.SupplyMinV = part.SupplyMinV
+	}
+	if out.SupplyMaxV == 0 {
+		out.SupplyMaxV = part.SupplyMaxV
+	}
+	if out.Output == "" {
+		out.Output = part.Output
+	}
+	out.SupplyCurrent = mergeSupplyCurrent(out.SupplyCurrent, part.SupplyCurrent)
+	return out
+}
+
+// mergeSupplyCurrent fills unset supply current fields from part defaults.
+func mergeSupplyCurrent(in, part model.SupplyCurrent) model.SupplyCurrent {
+	out := in
+	if out.QuiescentA == 0 {
+		out.QuiescentA = part.QuiescentA
+	}
+	if out.TypicalA == 0 {
+		out.TypicalA = part.TypicalA
+	}
+	if out.PeakA == 0 {
+		out.PeakA = part.PeakA
+	}
+	return out
+}
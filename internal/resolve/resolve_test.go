@@ -3,6 +3,7 @@ package resolve_test
 import (
 	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/badimirzai/robotics-verifier-cli/internal/model"
@@ -148,3 +149,258 @@ func TestResolveAll_MotorCountZeroIsError(t *testing.T) {
 		t.Fatalf("expected ResolveAll to return error when motor count is zero, got nil")
 	}
 }
+
+func TestResolveAll_FillsSupplyCurrentsFromParts(t *testing.T) {
+	store := parts.NewStore(testPartsDir(t))
+
+	raw := model.RobotSpec{
+		MCU: model.MCU{
+			Part:          "mcus/esp32s3",
+			SupplyCurrent: model.SupplyCurrent{TypicalA: 0.2}, // explicit override
+		},
+		Driver: model.MotorDriver{Part: "drivers/tb6612fng"},
+		I2CBuses: []model.I2CBus{{
+			Name:    "i2c0",
+			Devices: []model.I2CDevice{{Part: "sensors/vl53l0x"}},
+		}},
+	}
+
+	resolved, err := resolve.ResolveAll(raw, store)
+	if err != nil {
+		t.Fatalf("ResolveAll returned error: %v", err)
+	}
+
+	if resolved.MCU.SupplyCurrent.TypicalA != 0.2 {
+		t.Errorf("expected explicit MCU typical current 0.2A to win, got %.4f", resolved.MCU.SupplyCurrent.TypicalA)
+	}
+	if resolved.MCU.SupplyCurrent.PeakA == 0 {
+		t.Errorf("expected MCU peak current to be filled from part")
+	}
+	if resolved.Driver.LogicCurrent.TypicalA == 0 {
+		t.Errorf("expected driver logic current to be filled from part")
+	}
+	if resolved.I2CBuses[0].Devices[0].SupplyCurrent.TypicalA == 0 {
+		t.Errorf("expected sensor supply current to be filled from part")
+	}
+}
+
+func TestResolveAll_MotorDriversList(t *testing.T) {
+	store := parts.NewStore(testPartsDir(t))
+
+	raw := model.RobotSpec{
+		MCU: model.MCU{LogicVoltageV: 3.3},
+		Drivers: []model.MotorDriver{
+			{Part: "drivers/tb6612fng", Name: "front"},
+			{Part: "drivers/tb6612fng", Name: "rear"},
+		},
+	}
+
+	resolved, err := resolve.ResolveAll(raw, store)
+	if err != nil {
+		t.Fatalf("ResolveAll returned error: %v", err)
+	}
+	if len(resolved.Drivers) != 2 {
+		t.Fatalf("expected 2 drivers after resolving, got %d", len(resolved.Drivers))
+	}
+	for i, d := range resolved.Drivers {
+		if d.Channels != 2 {
+			t.Errorf("motor_drivers[%d]: expected channels from part, got %d", i, d.Channels)
+		}
+	}
+	if resolved.Drivers[0].Name != "front" {
+		t.Errorf("expected explicit driver name to win, got %q", resolved.Drivers[0].Name)
+	}
+
+	raw.Drivers[1] = model.MotorDriver{Name: "rear"}
+	_, err = resolve.ResolveAll(raw, store)
+	if err == nil {
+		t.Fatalf("expected error for motor_drivers entry missing required values")
+	}
+	if want := "motor_drivers[1]: channels must be > 0 after resolving"; err.Error() != want {
+		t.Errorf("error = %q, want %q", err, want)
+	}
+
+	raw.Drivers = nil
+	raw.Driver = model.MotorDriver{Part: "drivers/tb6612fng", Channels: -1}
+	_, err = resolve.ResolveAll(raw, store)
+	if err == nil || !strings.HasPrefix(err.Error(), "motor_driver: channels") {
+		t.Errorf("expected a motor_driver: prefixed error, got %v", err)
+	}
+}
+
+func TestResolveAll_SteppersWithoutDCDriver(t *testing.T) {
+	store := parts.NewStore(testPartsDir(t))
+
+	raw := model.RobotSpec{
+		MCU: model.MCU{LogicVoltageV: 3.3},
+		Steppers: []model.Stepper{{
+			Name:   "shoulder",
+			Count:  1,
+			Motor:  model.StepperMotor{Part: "motors/nema17_17hs4401"},
+			Driver: model.StepperDriver{Part: "drivers/a4988", CurrentLimitA: 1.2},
+		}},
+	}
+
+	resolved, err := resolve.ResolveAll(raw, store)
+	if err != nil {
+		t.Fatalf("ResolveAll returned error: %v", err)
+	}
+	st := resolved.Steppers[0]
+	if st.Motor.PhaseCurrentA != 1.7 {
+		t.Errorf("expected phase current from part, got %.2f", st.Motor.PhaseCurrentA)
+	}
+	if st.Driver.MotorSupplyMaxV != 35 || len(st.Driver.SupportedMicrosteps) == 0 {
+		t.Errorf("expected driver values from part, got %+v", st.Driver)
+	}
+	if st.Driver.CurrentLimitA != 1.2 {
+		t.Errorf("expected explicit current limit to be kept, got %.2f", st.Driver.CurrentLimitA)
+	}
+
+	raw.Steppers[0].Count = 0
+	if _, err := resolve.ResolveAll(raw, store); err == nil {
+		t.Fatalf("expected error for stepper count 0")
+	}
+}
+
+func TestResolveAll_BLDCFromParts(t *testing.T) {
+	store := parts.NewStore(testPartsDir(t))
+
+	raw := model.RobotSpec{
+		MCU: model.MCU{LogicVoltageV: 3.3},
+		BLDC: []model.BLDC{{
+			Name:  "props",
+			Count: 4,
+			Motor: model.BLDCMotor{Part: "motors/bldc_2207_1750kv"},
+			ESC:   model.ESC{Part: "drivers/esc_30a_bec", BEC: model.BEC{Feeds: "logic_rail"}},
+		}},
+	}
+
+	resolved, err := resolve.ResolveAll(raw, store)
+	if err != nil {
+		t.Fatalf("ResolveAll returned error: %v", err)
+	}
+	b := resolved.BLDC[0]
+	if b.Motor.KV != 1750 || b.Motor.MaxCurrentA == 0 {
+		t.Errorf("expected motor values from part, got %+v", b.Motor)
+	}
+	if b.ESC.BurstA == 0 || b.ESC.BEC.VoltageV != 5 {
+		t.Errorf("expected ESC values from part, got %+v", b.ESC)
+	}
+	if b.ESC.BEC.Feeds != "logic_rail" {
+		t.Errorf("expected explicit bec.feeds to be kept, got %q", b.ESC.BEC.Feeds)
+	}
+}
+
+func TestResolveAll_ServosAndPWMControllers(t *testing.T) {
+	store := parts.NewStore(testPartsDir(t))
+
+	raw := model.RobotSpec{
+		MCU:    model.MCU{LogicVoltageV: 3.3},
+		Servos: []model.Servo{{Part: "motors/mg996r", Name: "wrist", Count: 1, StallCurrentA: 3}},
+		PWM:    []model.PWMController{{Part: "drivers/pca9685", Name: "pwm0", Bus: "i2c0", AddressHex: 0x41}},
+	}
+
+	resolved, err := resolve.ResolveAll(raw, store)
+	if err != nil {
+		t.Fatalf("ResolveAll returned error: %v", err)
+	}
+	sv := resolved.Servos[0]
+	if sv.VoltageMaxV != 7.2 || sv.SignalMinV == 0 {
+		t.Errorf("expected servo values from part, got %+v", sv)
+	}
+	if sv.StallCurrentA != 3 {
+		t.Errorf("expected explicit stall current to win, got %.2f", sv.StallCurrentA)
+	}
+	c := resolved.PWM[0]
+	if c.AddressHex != 0x41 || c.Channels != 16 {
+		t.Errorf("expected explicit address and part channels, got %+v", c)
+	}
+}
+
+func TestResolveAll_EncodersFromMotorPart(t *testing.T) {
+	store := parts.NewStore(testPartsDir(t))
+
+	raw := model.RobotSpec{
+		MCU:    model.MCU{Part: "mcus/esp32s3"},
+		Driver: model.MotorDriver{Part: "drivers/tb6612fng"},
+		Motors: []model.Motor{{Part: "motors/generic_dc_12v_gearmotor", Name: "drive", Count: 2}},
+		Encoders: []model.Encoder{
+			{Name: "left", Motor: "drive", PinA: "GPIO4", PinB: "GPIO5"},
+			{Name: "right", Motor: "drive", PinA: "GPIO6", PinB: "GPIO7", MotorEncoder: model.MotorEncoder{Output: "push_pull"}},
+		},
+	}
+
+	resolved, err := resolve.ResolveAll(raw, store)
+	if err != nil {
+		t.Fatalf("ResolveAll returned error: %v", err)
+	}
+	if m := resolved.Motors[0]; m.NoLoadRPM == 0 || m.Encoder.PPR != 11 {
+		t.Errorf("expected motor rpm and encoder from part, got %+v", m)
+	}
+	left, right := resolved.Encoders[0], resolved.Encoders[1]
+	if left.PPR != 11 || left.Output != "open_collector" || left.SupplyMinV != 3.3 {
+		t.Errorf("expected encoder defaults from motor part, got %+v", left)
+	}
+	if right.Output != "push_pull" || right.PPR != 11 {
+		t.Errorf("expected explicit output to win, got %+v", right)
+	}
+	if resolved.MCU.MaxInterruptRateHz == 0 {
+		t.Errorf("expected interrupt rate from MCU part")
+	}
+}
+
+func TestResolveAll_HarnessConnectors(t *testing.T) {
+	store := parts.NewStore(testPartsDir(t))
+
+	raw := model.RobotSpec{
+		MCU: model.MCU{LogicVoltageV: 5},
+		Harness: []model.HarnessSegment{{
+			Name: "battery lead",
+			AWG:  16,
+			Connectors: []model.Connector{
+				{Part: "connectors/xt60"},
+				{Part: "connectors/dupont", Name: "jumper", RatingA: 2},
+			},
+		}},
+	}
+
+	resolved, err := resolve.ResolveAll(raw, store)
+	if err != nil {
+		t.Fatalf("ResolveAll returned error: %v", err)
+	}
+	xt60, dupont := resolved.Harness[0].Connectors[0], resolved.Harness[0].Connectors[1]
+	if xt60.RatingA != 30 || xt60.PeakRatingA != 60 || xt60.Name == "" {
+		t.Errorf("expected xt60 ratings from part, got %+v", xt60)
+	}
+	if dupont.Name != "jumper" || dupont.RatingA != 2 {
+		t.Errorf("expected explicit name and rating to win, got %+v", dupont)
+	}
+
+	raw.Harness[0].Connectors = []model.Connector{{Part: "interfaces/txs0108e"}}
+	if _, err := resolve.ResolveAll(raw, store); err == nil {
+		t.Errorf("expected error resolving a level shifter as a connector")
+	}
+}
+
+func TestResolveAll_FiveVTolerantOverride(t *testing.T) {
+	store := parts.NewStore(testPartsDir(t))
+
+	raw := model.RobotSpec{MCU: model.MCU{Part: "mcus/arduino-uno-r3"}}
+	resolved, err := resolve.ResolveAll(raw, store)
+	if err != nil {
+		t.Fatalf("ResolveAll returned error: %v", err)
+	}
+	if tol := resolved.MCU.FiveVTolerant; tol == nil || !*tol {
+		t.Errorf("expected five_v_tolerant true from the Uno part, got %v", tol)
+	}
+
+	off := false
+	raw.MCU.FiveVTolerant = &off
+	resolved, err = resolve.ResolveAll(raw, store)
+	if err != nil {
+		t.Fatalf("ResolveAll returned error: %v", err)
+	}
+	if tol := resolved.MCU.FiveVTolerant; tol == nil || *tol {
+		t.Errorf("expected explicit five_v_tolerant false to win, got %v", tol)
+	}
+}
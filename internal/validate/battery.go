@@ -0,0 +1,312 @@
+package validate
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/badimirzai/robotics-verifier-cli/internal/model"
+)
+
+// cellVoltages is the per cell voltage range of a battery chemistry.
+type cellVoltages struct {
+	MinV     float64 // discharge cutoff
+	NominalV float64
+	MaxV     float64 // full charge
+}
+
+// cellChemistries is keyed by the normalized chemistry name, see chemistryKey.
+var cellChemistries = map[string]cellVoltages{
+	"lipo":     {MinV: 3.0, NominalV: 3.7, MaxV: 4.2},
+	"liion":    {MinV: 3.0, NominalV: 3.7, MaxV: 4.2},
+	"lihv":     {MinV: 3.0, NominalV: 3.8, MaxV: 4.35},
+	"lifepo4":  {MinV: 2.5, NominalV: 3.2, MaxV: 3.65},
+	"nimh":     {MinV: 1.0, NominalV: 1.2, MaxV: 1.45},
+	"nicd":     {MinV: 1.0, NominalV: 1.2, MaxV: 1.45},
+	"leadacid": {MinV: 1.75, NominalV: 2.0, MaxV: 2.3}, // max while on the charger
+	"sla":      {MinV: 1.75, NominalV: 2.0, MaxV: 2.3},
+}
+
+func chemistryKey(name string) string {
+	r := strings.NewReplacer("-", "", "_", "", " ", "")
+	return r.Replace(strings.ToLower(strings.TrimSpace(name)))
+}
+
+func chemistryFor(name string) (cellVoltages, bool) {
+	c, ok := cellChemistries[chemistryKey(name)]
+	return c, ok
+}
+
+// batteryCells returns the series cell count: declared, or derived from voltage_v when
+// it is within 10% of a whole number of nominal cells.
+func batteryCells(b model.Battery) int {
+	if b.Cells > 0 {
+		return b.Cells
+	}
+	chem, ok := chemistryFor(b.Chemistry)
+	if !ok || b.VoltageV <= 0 {
+		return 0
+	}
+	cells := int(math.Round(b.VoltageV / chem.NominalV))
+	if cells < 1 || math.Abs(float64(cells)*chem.NominalV-b.VoltageV) > 0.1*b.VoltageV {
+		return 0
+	}
+	return cells
+}
+
+// batteryNominalV returns voltage_v, or cells × nominal cell voltage when only the
+// chemistry and cell count are declared.
+func batteryNominalV(spec model.RobotSpec) float64 {
+	b := spec.Power.Battery
+	if b.VoltageV != 0 {
+		return b.VoltageV
+	}
+	if chem, ok := chemistryFor(b.Chemistry); ok && b.Cells > 0 {
+		return float64(b.Cells) * chem.NominalV
+	}
+	return 0
+}
+
+// batteryPeakA is the worst case battery current: every motor, DC, servo and stepper,
+// at its peak through the regulators feeding it, the logic consumers at their
+// continuous draw and every brushless drive at full throttle. The C-rate, stall sag and
+// battery harness checks all use it.
+func batteryPeakA(spec model.RobotSpec) float64 {
+	return railLoadsFrom(spec, stallLoads(spec))[batterySource].ContinuousA + bldcPeakA(spec)
+}
+
+// stallLoads is directLoads with every motor, DC, servo and stepper, at its peak and
+// the logic consumers at their continuous draw.
+func stallLoads(spec model.RobotSpec) map[string]railLoad {
+	logic := make(map[string]railLoad)
+	for _, c := range logicConsumers(spec) {
+		logic[c.Rail] = logic[c.Rail].add(supplyLoad(c.Current))
+	}
+	out := make(map[string]railLoad)
+	for name, l := range directLoads(spec) {
+		lg := logic[name]
+		out[name] = railLoad{ContinuousA: l.PeakA - lg.PeakA + lg.ContinuousA}
+	}
+	return out
+}
+
+// batteryCorner is a battery voltage other than nominal that supply windows must cover.
+type batteryCorner struct {
+	Name     string
+	VoltageV float64
+	Detail   string
+	Severity Severity
+	Stall    bool // every motor stalled, so harness runs drop their stall voltage
+}
+
+// batteryCorners returns the empty and full charge voltages of a known chemistry and the
+// voltage under total stall current when the pack resistance is declared. Running empty
+// or full is a normal operating point; sag only lasts while the motors stall.
+func batteryCorners(spec model.RobotSpec) []batteryCorner {
+	b := spec.Power.Battery
+	var out []batteryCorner
+	baseV, baseName := batteryNominalV(spec), "nominal"
+	if chem, ok := chemistryFor(b.Chemistry); ok {
+		if cells := batteryCells(b); cells > 0 {
+			pack := fmt.Sprintf("%dS %s", cells, b.Chemistry)
+			baseV, baseName = float64(cells)*chem.MinV, "empty"
+			out = append(out,
+				batteryCorner{
+					Name:     "empty",
+					VoltageV: baseV,
+					Detail:   fmt.Sprintf("%s at %.2fV/cell", pack, chem.MinV),
+					Severity: SevError,
+				},
+				batteryCorner{
+					Name:     "full charge",
+					VoltageV: float64(cells) * chem.MaxV,
+					Detail:   fmt.Sprintf("%s at %.2fV/cell", pack, chem.MaxV),
+					Severity: SevError,
+				},
+			)
+		}
+	}
+	r := b.InternalResistanceOhm
+	peakA := batteryPeakA(spec)
+	if r > 0 && peakA > 0 && baseV > peakA*r {
+		out = append(out, batteryCorner{
+			Name:     "stall sag",
+			VoltageV: baseV - peakA*r,
+			Detail:   fmt.Sprintf("%s %.2fV - %.2fA x %.3fΩ", baseName, baseV, peakA, r),
+			Severity: SevWarn,
+			Stall:    true,
+		})
+	}
+	return out
+}
+
+// supplyWindow is the supply voltage range one consumer of a battery or rail accepts.
+type supplyWindow struct {
+	Supply     supplyPoint
+	MinV       float64
+	MaxV       float64
+	Code       string
+	Subject    string  // e.g. "motor_driver motor supply range"
+	DropV      float64 // harness drop at nominal current
+	StallDropV float64 // harness drop with every motor stalled
+}
+
+func (w supplyWindow) outside(v float64) bool {
+	return v < w.MinV || v > w.MaxV
+}
+
+// loadedV is the voltage at the consumer's terminals in normal running.
+func (w supplyWindow) loadedV() float64 {
+	return w.Supply.VoltageV - w.DropV
+}
+
+// stallV is the voltage at the consumer's terminals with every motor stalled.
+func (w supplyWindow) stallV() float64 {
+	return w.Supply.VoltageV - w.StallDropV
+}
+
+// finding reports the consumer at v outside the window; at names a battery corner.
+// Harness drops show in the message and as derived values in meta.
+func (w supplyWindow) finding(locs map[string]Location, sev Severity, v float64, at string) Finding {
+	f := Finding{
+		Severity: sev,
+		Code:     w.Code,
+		Message: fmt.Sprintf(
+			"%s %.2fV%s outside %s [%.2f, %.2f]V",
+			w.Supply.Label(),
+			v,
+			at,
+			w.Subject,
+			w.MinV,
+			w.MaxV,
+		),
+	}
+	if drop := w.Supply.VoltageV - v; w.StallDropV > 0 && drop > 0 {
+		f.Message = fmt.Sprintf(
+			"%s %.2fV%s is %.2fV after a %.2fV harness drop, outside %s [%.2f, %.2f]V",
+			w.Supply.Label(),
+			w.Supply.VoltageV,
+			at,
+			v,
+			drop,
+			w.Subject,
+			w.MinV,
+			w.MaxV,
+		)
+		f.Meta = map[string]any{
+			"supply_v":             roundMilli(w.Supply.VoltageV),
+			"load_v":               roundMilli(v),
+			"harness_drop_v":       roundMilli(drop),
+			"harness_stall_drop_v": roundMilli(w.StallDropV),
+		}
+	}
+	return withLocation(locs, w.Supply.Path, f)
+}
+
+func driverSupplyWindow(spec model.RobotSpec, e driverEntry) (supplyWindow, bool) {
+	drv := e.Driver
+	return newSupplyWindow(spec, drv.SupplyRail, drv.MotorSupplyMinV, drv.MotorSupplyMaxV,
+		"DRV_SUPPLY_RANGE", e.Path+" motor supply range", e.Path, drv.Name)
+}
+
+func stepperSupplyWindow(spec model.RobotSpec, i int, st model.Stepper) (supplyWindow, bool) {
+	drv := st.Driver
+	return newSupplyWindow(spec, drv.SupplyRail, drv.MotorSupplyMinV, drv.MotorSupplyMaxV,
+		"DRV_SUPPLY_RANGE", fmt.Sprintf("steppers[%d].driver motor supply range", i))
+}
+
+func servoSupplyWindow(spec model.RobotSpec, sv model.Servo) (supplyWindow, bool) {
+	return newSupplyWindow(spec, sv.SupplyRail, sv.VoltageMinV, sv.VoltageMaxV,
+		"SERVO_SUPPLY_RANGE", fmt.Sprintf("servo %s operating range", sv.Name))
+}
+
+// newSupplyWindow builds the window of a consumer on rail. Harness segments carrying
+// the supply, or one of the consumer's names, drop voltage before it.
+func newSupplyWindow(spec model.RobotSpec, rail string, minV, maxV float64, code, subject string, names ...string) (supplyWindow, bool) {
+	supply, ok := motorSupply(spec, rail)
+	if !ok || supply.VoltageV <= 0 || minV == 0 || maxV == 0 {
+		return supplyWindow{}, false
+	}
+	w := supplyWindow{Supply: supply, MinV: minV, MaxV: maxV, Code: code, Subject: subject}
+	w.DropV, w.StallDropV = harnessDropV(spec, append(names, supply.Name)...)
+	return w, true
+}
+
+// supplyWindows lists the supply windows of every motor side consumer.
+func supplyWindows(spec model.RobotSpec) []supplyWindow {
+	var out []supplyWindow
+	for _, e := range driverEntries(spec) {
+		if w, ok := driverSupplyWindow(spec, e); ok {
+			out = append(out, w)
+		}
+	}
+	for i, st := range spec.Steppers {
+		if w, ok := stepperSupplyWindow(spec, i, st); ok {
+			out = append(out, w)
+		}
+	}
+	for _, sv := range spec.Servos {
+		if w, ok := servoSupplyWindow(spec, sv); ok {
+			out = append(out, w)
+		}
+	}
+	return out
+}
+
+// ruleBatteryCorners repeats the supply range checks with the battery empty, fully
+// charged and sagging under stall. Windows that already fail at nominal voltage are
+// reported by their own rules.
+func ruleBatteryCorners(spec model.RobotSpec, locs map[string]Location) []Finding {
+	var out []Finding
+	b := spec.Power.Battery
+	if chem, ok := chemistryFor(b.Chemistry); ok && b.Cells > 0 && b.VoltageV > 0 {
+		minV, maxV := float64(b.Cells)*chem.MinV, float64(b.Cells)*chem.MaxV
+		if b.VoltageV < minV || b.VoltageV > maxV {
+			out = append(out, withLocation(locs, "power.battery.voltage_v", Finding{
+				Severity: SevWarn,
+				Code:     "BATT_CELLS_MISMATCH",
+				Message: fmt.Sprintf(
+					"battery voltage_v %.2fV outside the %dS %s range [%.2f, %.2f]V",
+					b.VoltageV,
+					b.Cells,
+					b.Chemistry,
+					minV,
+					maxV,
+				),
+			}))
+		}
+	}
+
+	corners := batteryCorners(spec)
+	if len(corners) == 0 {
+		return out
+	}
+	failsNominal := make(map[string]bool)
+	for _, w := range supplyWindows(spec) {
+		if w.outside(w.loadedV()) {
+			failsNominal[w.Code+" "+w.Subject] = true
+		}
+	}
+	parts := []string{fmt.Sprintf("nominal %.2fV", batteryNominalV(spec))}
+	for _, c := range corners {
+		parts = append(parts, fmt.Sprintf("%s %.2fV", c.Name, c.VoltageV))
+		at := spec
+		at.Power.Battery.VoltageV = c.VoltageV
+		for _, w := range supplyWindows(at) {
+			v := w.loadedV()
+			if c.Stall {
+				v = w.stallV()
+			}
+			if failsNominal[w.Code+" "+w.Subject] || !w.outside(v) {
+				continue
+			}
+			out = append(out, w.finding(locs, c.Severity, v, fmt.Sprintf(" at %s (%s)", c.Name, c.Detail)))
+		}
+	}
+	out = append(out, withLocation(locs, "power.battery", Finding{
+		Severity: SevInfo,
+		Code:     "BATT_CORNERS",
+		Message:  "supply ranges checked at battery " + strings.Join(parts, ", "),
+	}))
+	return out
+}
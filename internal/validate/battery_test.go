@@ -0,0 +1,157 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/badimirzai/robotics-verifier-cli/internal/model"
+)
+
+// lipoSpec runs the base robot from a 3S LiPo with a 6-12V driver.
+func lipoSpec() model.RobotSpec {
+	spec := baseSpec()
+	spec.Power.Battery = model.Battery{Chemistry: "LiPo", VoltageV: 11.1}
+	spec.Driver.MotorSupplyMaxV = 12
+	return spec
+}
+
+func TestRuleBatteryCorners(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*model.RobotSpec)
+		want   []string
+		not    []string
+	}{
+		{
+			name:   "full_charge_over_driver_max",
+			mutate: func(s *model.RobotSpec) {},
+			want:   []string{"DRV_SUPPLY_RANGE", "BATT_CORNERS"},
+		},
+		{
+			name: "range_covers_all_corners",
+			mutate: func(s *model.RobotSpec) {
+				s.Driver.MotorSupplyMaxV = 16
+			},
+			want: []string{"BATT_CORNERS"},
+			not:  []string{"DRV_SUPPLY_RANGE", "BATT_CELLS_MISMATCH"},
+		},
+		{
+			name: "unknown_chemistry",
+			mutate: func(s *model.RobotSpec) {
+				s.Power.Battery.Chemistry = "mystery"
+			},
+			not: []string{"DRV_SUPPLY_RANGE", "BATT_CORNERS"},
+		},
+		{
+			name: "sag_under_stall",
+			mutate: func(s *model.RobotSpec) {
+				s.Driver.MotorSupplyMinV = 8.5
+				s.Driver.MotorSupplyMaxV = 16
+				s.Power.Battery.InternalResistanceOhm = 0.1
+			},
+			want: []string{"DRV_SUPPLY_RANGE"},
+		},
+		{
+			name: "cells_disagree_with_voltage",
+			mutate: func(s *model.RobotSpec) {
+				s.Power.Battery.Cells = 4
+				s.Driver.MotorSupplyMaxV = 20
+			},
+			want: []string{"BATT_CELLS_MISMATCH"},
+		},
+		{
+			name: "servo_window",
+			mutate: func(s *model.RobotSpec) {
+				s.Driver.MotorSupplyMaxV = 16
+				s.Power.Battery.VoltageV = 7.4
+				s.Driver.MotorSupplyMinV = 4.5
+				s.Servos = []model.Servo{{Name: "hv", Count: 1, VoltageMinV: 6, VoltageMaxV: 8.4}}
+				s.Power.Battery.Chemistry = "LiHV"
+			},
+			want: []string{"SERVO_SUPPLY_RANGE"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := lipoSpec()
+			tt.mutate(&spec)
+			codes := reportCodes(RunAll(spec, nil))
+			for _, code := range tt.want {
+				requireHasCode(t, codes, code)
+			}
+			for _, code := range tt.not {
+				requireNoCode(t, codes, code)
+			}
+		})
+	}
+}
+
+func TestBatteryCornerNamesFailingCorner(t *testing.T) {
+	spec := lipoSpec()
+	var msgs []string
+	for _, f := range RunAll(spec, nil).Findings {
+		if f.Code == "DRV_SUPPLY_RANGE" {
+			msgs = append(msgs, f.Message)
+		}
+	}
+	if len(msgs) != 1 || !strings.Contains(msgs[0], "12.60V at full charge (3S LiPo") {
+		t.Fatalf("expected one full charge finding, got %q", msgs)
+	}
+
+	// A window that already fails at nominal is not repeated per corner.
+	spec.Driver.MotorSupplyMaxV = 10
+	msgs = nil
+	for _, f := range RunAll(spec, nil).Findings {
+		if f.Code == "DRV_SUPPLY_RANGE" {
+			msgs = append(msgs, f.Message)
+		}
+	}
+	if len(msgs) != 1 || strings.Contains(msgs[0], " at ") {
+		t.Fatalf("expected only the nominal finding, got %q", msgs)
+	}
+}
+
+func TestBatteryNominalFromCells(t *testing.T) {
+	spec := baseSpec()
+	spec.Power.Battery = model.Battery{Chemistry: "LiFePO4", Cells: 4}
+	if got := batteryNominalV(spec); got != 12.8 {
+		t.Fatalf("nominal = %.2f, want 12.80", got)
+	}
+	if got := batteryCells(model.Battery{Chemistry: "Li-ion", VoltageV: 12}); got != 3 {
+		t.Fatalf("cells = %d, want 3", got)
+	}
+	if got := batteryCells(model.Battery{Chemistry: "Li-ion", VoltageV: 5}); got != 0 {
+		t.Fatalf("cells = %d, want 0 for a voltage between cell counts", got)
+	}
+}
+
+func TestBatteryPeakCountsServos(t *testing.T) {
+	spec := baseSpec()
+	spec.Motors = nil
+	spec.Power.Battery = model.Battery{
+		Chemistry: "LiPo", VoltageV: 7.4, CapacityAh: 1, CRating: 5, InternalResistanceOhm: 0.05,
+	}
+	spec.Servos = []model.Servo{{
+		Name: "mg996r", Count: 6, VoltageMinV: 4.8, VoltageMaxV: 8.4, IdleCurrentA: 0.01, StallCurrentA: 2.5,
+	}}
+	spec.Harness = []model.HarnessSegment{{Name: "battery lead", AWG: 14, FuseA: 20}}
+
+	peakA := batteryPeakA(spec)
+	if peakA < 15 {
+		t.Fatalf("expected six stalled servos in the battery peak, got %.2fA", peakA)
+	}
+	requireHasCode(t, reportCodes(RunAll(spec, nil)), "BATT_PEAK_OVER_C")
+
+	sag := false
+	for _, c := range batteryCorners(spec) {
+		sag = sag || (c.Name == "stall sag" && strings.Contains(c.Detail, fmt.Sprintf("%.2fA", peakA)))
+	}
+	if !sag {
+		t.Errorf("expected a stall sag corner at %.2fA, got %+v", peakA, batteryCorners(spec))
+	}
+	if load, _ := segmentLoad(spec, ""); load.StallA != peakA {
+		t.Errorf("expected the battery lead stall %.2fA to match the battery peak %.2fA", load.StallA, peakA)
+	}
+}
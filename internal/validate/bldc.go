@@ -0,0 +1,210 @@
+package validate
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/badimirzai/robotics-verifier-cli/internal/model"
+)
+
+// becLogicRail is the bec.feeds value for the single power.logic_rail.
+const becLogicRail = "logic_rail"
+
+// bldcPeakA is the battery current of all brushless drives at full throttle.
+func bldcPeakA(spec model.RobotSpec) float64 {
+	total := 0.0
+	for _, b := range spec.BLDC {
+		if b.Count <= 0 || b.Motor.MaxCurrentA <= 0 {
+			continue
+		}
+		total += b.Motor.MaxCurrentA * float64(b.Count)
+	}
+	return total
+}
+
+func ruleBLDCCells(spec model.RobotSpec, locs map[string]Location) []Finding {
+	cells := batteryCells(spec.Power.Battery)
+	if cells <= 0 {
+		return nil
+	}
+	var out []Finding
+	for i, b := range spec.BLDC {
+		base := fmt.Sprintf("bldc[%d]", i)
+		if outsideCells(cells, b.ESC.MinCells, b.ESC.MaxCells) {
+			out = append(out, withLocation(locs, "power.battery.cells", Finding{
+				Severity: SevError,
+				Code:     "BLDC_CELLS_ESC",
+				Message: fmt.Sprintf(
+					"battery %dS outside %s.esc supported %s",
+					cells,
+					base,
+					cellRange(b.ESC.MinCells, b.ESC.MaxCells),
+				),
+			}))
+		}
+		if outsideCells(cells, b.Motor.MinCells, b.Motor.MaxCells) {
+			out = append(out, withLocation(locs, "power.battery.cells", Finding{
+				Severity: SevError,
+				Code:     "BLDC_CELLS_MOTOR",
+				Message: fmt.Sprintf(
+					"battery %dS outside %s.motor rated %s",
+					cells,
+					base,
+					cellRange(b.Motor.MinCells, b.Motor.MaxCells),
+				),
+			}))
+		}
+	}
+	return out
+}
+
+// outsideCells reports whether cells falls outside [min, max]; unset bounds are open.
+func outsideCells(cells, min, max int) bool {
+	return (min > 0 && cells < min) || (max > 0 && cells > max)
+}
+
+func cellRange(min, max int) string {
+	switch {
+	case min > 0 && max > 0:
+		return fmt.Sprintf("%d-%dS", min, max)
+	case min > 0:
+		return fmt.Sprintf(">= %dS", min)
+	default:
+		return fmt.Sprintf("<= %dS", max)
+	}
+}
+
+func ruleBLDCCurrent(spec model.RobotSpec, locs map[string]Location) []Finding {
+	var out []Finding
+	for i, b := range spec.BLDC {
+		base := fmt.Sprintf("bldc[%d]", i)
+		motorA := b.Motor.MaxCurrentA
+		if motorA <= 0 {
+			continue
+		}
+		if b.ESC.BurstA > 0 && b.ESC.BurstA < motorA {
+			out = append(out, withLocation(locs, base+".esc.burst_a", Finding{
+				Severity: SevError,
+				Code:     "BLDC_ESC_BURST_LOW",
+				Message: fmt.Sprintf(
+					"%s ESC burst %.2fA < motor max current %.2fA",
+					b.Name,
+					b.ESC.BurstA,
+					motorA,
+				),
+			}))
+			continue
+		}
+		if b.ESC.ContinuousA > 0 && b.ESC.ContinuousA < motorA {
+			out = append(out, withLocation(locs, base+".esc.continuous_a", Finding{
+				Severity: SevWarn,
+				Code:     "BLDC_ESC_CONT_LOW",
+				Message: fmt.Sprintf(
+					"%s ESC continuous %.2fA < motor max current %.2fA. Full throttle is limited to burst duration.",
+					b.Name,
+					b.ESC.ContinuousA,
+					motorA,
+				),
+			}))
+		}
+	}
+	return out
+}
+
+// ruleBLDCBEC checks an ESC BEC that powers a logic rail: its voltage must match the
+// rail and its current rating must cover the rail load.
+func ruleBLDCBEC(spec model.RobotSpec, locs map[string]Location) []Finding {
+	var out []Finding
+	loads := railLoads(spec)
+	for i, b := range spec.BLDC {
+		bec := b.ESC.BEC
+		if bec.Feeds == "" {
+			continue
+		}
+		base := fmt.Sprintf("bldc[%d].esc.bec", i)
+		railName := bec.Feeds
+		if railName == becLogicRail {
+			railName = legacyLogicRail
+		}
+		rail, ok := logicSupply(spec, railName)
+		if !ok {
+			continue // RAIL_UNKNOWN
+		}
+		if bec.VoltageV <= 0 && bec.MaxCurrentA <= 0 {
+			out = append(out, withLocation(locs, base+".feeds", Finding{
+				Severity: SevWarn,
+				Code:     "BEC_UNRATED",
+				Message:  fmt.Sprintf("%s feeds %s but declares no voltage_v or max_current_a", base, rail.Label()),
+			}))
+			continue
+		}
+		if b.Count > 1 {
+			out = append(out, withLocation(locs, base+".feeds", Finding{
+				Severity: SevWarn,
+				Code:     "BEC_PARALLEL",
+				Message: fmt.Sprintf(
+					"%d %s ESC BECs feed %s in parallel; use one BEC and disconnect the others' power lead",
+					b.Count,
+					b.Name,
+					rail.Label(),
+				),
+			}))
+		}
+		if bec.VoltageV > 0 && rail.VoltageV > 0 && math.Abs(bec.VoltageV-rail.VoltageV) > 0.25 {
+			out = append(out, withLocation(locs, base+".voltage_v", Finding{
+				Severity: SevError,
+				Code:     "BEC_V_MISMATCH",
+				Message: fmt.Sprintf(
+					"%s BEC %.2fV does not match %s %.2fV",
+					b.Name,
+					bec.VoltageV,
+					rail.Label(),
+					rail.VoltageV,
+				),
+			}))
+		}
+		if bec.MaxCurrentA <= 0 {
+			continue
+		}
+		load := loads[railName]
+		switch {
+		case load.ContinuousA > bec.MaxCurrentA:
+			out = append(out, withLocation(locs, base+".max_current_a", Finding{
+				Severity: SevError,
+				Code:     "BEC_OVERLOAD",
+				Message: fmt.Sprintf(
+					"%s load %.2fA exceeds %s BEC rating %.2fA",
+					rail.Label(),
+					load.ContinuousA,
+					b.Name,
+					bec.MaxCurrentA,
+				),
+			}))
+		case load.PeakA > bec.MaxCurrentA:
+			out = append(out, withLocation(locs, base+".max_current_a", Finding{
+				Severity: SevWarn,
+				Code:     "BEC_PEAK_OVERLOAD",
+				Message: fmt.Sprintf(
+					"%s peak load %.2fA exceeds %s BEC rating %.2fA",
+					rail.Label(),
+					load.PeakA,
+					b.Name,
+					bec.MaxCurrentA,
+				),
+			}))
+		case rail.MaxCurrentA > bec.MaxCurrentA:
+			out = append(out, withLocation(locs, base+".max_current_a", Finding{
+				Severity: SevWarn,
+				Code:     "BEC_RAIL_RATING",
+				Message: fmt.Sprintf(
+					"%s is budgeted at %.2fA but its %s BEC supplies %.2fA",
+					rail.Label(),
+					rail.MaxCurrentA,
+					b.Name,
+					bec.MaxCurrentA,
+				),
+			}))
+		}
+	}
+	return out
+}
@@ -0,0 +1,165 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/badimirzai/robotics-verifier-cli/internal/model"
+)
+
+func bldcSpec() model.RobotSpec {
+	spec := baseSpec()
+	spec.Driver = model.MotorDriver{}
+	spec.Motors = nil
+	spec.Power.Battery = model.Battery{VoltageV: 14.8, Cells: 4, MaxDischargeA: 200}
+	spec.Power.Rail = model.Rail{VoltageV: 5, MaxCurrentA: 2}
+	spec.BLDC = []model.BLDC{{
+		Name:  "props",
+		Count: 4,
+		Motor: model.BLDCMotor{KV: 1750, MaxCurrentA: 35, MinCells: 4, MaxCells: 6},
+		ESC:   model.ESC{ContinuousA: 45, BurstA: 55, MinCells: 3, MaxCells: 6},
+	}}
+	return spec
+}
+
+func TestRuleBLDC(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*model.RobotSpec)
+		want   []string
+		not    []string
+	}{
+		{
+			name:   "clean_quad",
+			mutate: func(s *model.RobotSpec) {},
+			not: []string{
+				"BLDC_CELLS_ESC", "BLDC_CELLS_MOTOR", "BLDC_ESC_BURST_LOW", "BLDC_ESC_CONT_LOW",
+				"BATT_PEAK_OVER_C", "BATT_PEAK_MARGIN_LOW", "DRV_CHANNELS_INVALID",
+			},
+		},
+		{
+			name: "battery_below_motor_cells",
+			mutate: func(s *model.RobotSpec) {
+				s.Power.Battery.Cells = 3
+			},
+			want: []string{"BLDC_CELLS_MOTOR"},
+			not:  []string{"BLDC_CELLS_ESC"},
+		},
+		{
+			name: "battery_above_esc_cells",
+			mutate: func(s *model.RobotSpec) {
+				s.Power.Battery.Cells = 8
+			},
+			want: []string{"BLDC_CELLS_ESC", "BLDC_CELLS_MOTOR"},
+		},
+		{
+			name: "esc_burst_below_motor_max",
+			mutate: func(s *model.RobotSpec) {
+				s.BLDC[0].ESC.BurstA = 30
+			},
+			want: []string{"BLDC_ESC_BURST_LOW"},
+		},
+		{
+			name: "esc_continuous_below_motor_max",
+			mutate: func(s *model.RobotSpec) {
+				s.BLDC[0].ESC.ContinuousA = 30
+			},
+			want: []string{"BLDC_ESC_CONT_LOW"},
+			not:  []string{"BLDC_ESC_BURST_LOW"},
+		},
+		{
+			name: "bldc_current_in_battery_c_rate",
+			mutate: func(s *model.RobotSpec) {
+				s.Power.Battery.MaxDischargeA = 120
+			},
+			want: []string{"BATT_PEAK_OVER_C"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := bldcSpec()
+			tt.mutate(&spec)
+			codes := reportCodes(RunAll(spec, nil))
+			for _, c := range tt.want {
+				requireHasCode(t, codes, c)
+			}
+			for _, c := range tt.not {
+				requireNoCode(t, codes, c)
+			}
+		})
+	}
+}
+
+func TestRuleBLDCBEC(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*model.RobotSpec)
+		want   []string
+		not    []string
+	}{
+		{
+			name:   "bec_matches_rail",
+			mutate: func(s *model.RobotSpec) {},
+			not:    []string{"BEC_V_MISMATCH", "BEC_OVERLOAD", "BEC_PEAK_OVERLOAD", "BEC_RAIL_RATING", "BEC_PARALLEL"},
+		},
+		{
+			name: "bec_voltage_mismatch",
+			mutate: func(s *model.RobotSpec) {
+				s.Power.Rail.VoltageV = 3.3
+			},
+			want: []string{"BEC_V_MISMATCH"},
+		},
+		{
+			name: "bec_overloaded",
+			mutate: func(s *model.RobotSpec) {
+				s.MCU.SupplyCurrent = model.SupplyCurrent{TypicalA: 2.5}
+			},
+			want: []string{"BEC_OVERLOAD"},
+		},
+		{
+			name: "bec_peak_overload",
+			mutate: func(s *model.RobotSpec) {
+				s.MCU.SupplyCurrent = model.SupplyCurrent{TypicalA: 1, PeakA: 2.5}
+			},
+			want: []string{"BEC_PEAK_OVERLOAD"},
+			not:  []string{"BEC_OVERLOAD"},
+		},
+		{
+			name: "rail_budget_above_bec",
+			mutate: func(s *model.RobotSpec) {
+				s.Power.Rail.MaxCurrentA = 3
+			},
+			want: []string{"BEC_RAIL_RATING"},
+		},
+		{
+			name: "several_becs_in_parallel",
+			mutate: func(s *model.RobotSpec) {
+				s.BLDC[0].Count = 4
+			},
+			want: []string{"BEC_PARALLEL"},
+		},
+		{
+			name: "bec_feeds_unknown_rail",
+			mutate: func(s *model.RobotSpec) {
+				s.BLDC[0].ESC.BEC.Feeds = "5v"
+			},
+			want: []string{"RAIL_UNKNOWN"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := bldcSpec()
+			spec.BLDC[0].Count = 1
+			spec.BLDC[0].ESC.BEC = model.BEC{VoltageV: 5, MaxCurrentA: 2, Feeds: "logic_rail"}
+			tt.mutate(&spec)
+			codes := reportCodes(RunAll(spec, nil))
+			for _, c := range tt.want {
+				requireHasCode(t, codes, c)
+			}
+			for _, c := range tt.not {
+				requireNoCode(t, codes, c)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/badimirzai/robotics-verifier-cli/internal/model"
+)
+
+// canTerminationOhm is the ISO 11898-2 terminator fitted at each end of the bus.
+const canTerminationOhm = 120.0
+
+// canTermination is one terminator on a CAN bus.
+type canTermination struct {
+	Ohm   float64
+	Label string
+	Path  string
+}
+
+func canTerminations(bus model.CANBus, busIndex int) []canTermination {
+	var out []canTermination
+	for i, ohm := range bus.TerminationsOhm {
+		if ohm <= 0 {
+			continue
+		}
+		out = append(out, canTermination{ohm, "resistor", fmt.Sprintf("can_buses[%d].terminations_ohm[%d]", busIndex, i)})
+	}
+	for i, n := range bus.Nodes {
+		if n.TerminationOhm <= 0 {
+			continue
+		}
+		out = append(out, canTermination{n.TerminationOhm, n.Name, fmt.Sprintf("can_buses[%d].nodes[%d].termination_ohm", busIndex, i)})
+	}
+	return out
+}
+
+// supplyWithin returns the first rail whose voltage falls inside [minV, maxV].
+func supplyWithin(spec model.RobotSpec, minV, maxV float64) (supplyPoint, bool) {
+	candidates := []supplyPoint{}
+	if p, ok := logicSupply(spec, ""); ok && p.VoltageV > 0 {
+		candidates = append(candidates, p)
+	}
+	for _, r := range spec.Power.Rails {
+		if p, ok := namedSupply(spec, r.Name); ok {
+			candidates = append(candidates, p)
+		}
+	}
+	for _, p := range candidates {
+		if (minV <= 0 || p.VoltageV >= minV) && (maxV <= 0 || p.VoltageV <= maxV) {
+			return p, true
+		}
+	}
+	return supplyPoint{}, false
+}
+
+// voltageWindow describes a supply range where either bound may be unset.
+func voltageWindow(minV, maxV float64) string {
+	switch {
+	case maxV <= 0:
+		return fmt.Sprintf("at least %.2fV", minV)
+	case minV <= 0:
+		return fmt.Sprintf("at most %.2fV", maxV)
+	}
+	return fmt.Sprintf("%.2f..%.2fV", minV, maxV)
+}
+
+func ruleCAN(spec model.RobotSpec, locs map[string]Location) []Finding {
+	var out []Finding
+	for busIndex, bus := range spec.CANBuses {
+		base := fmt.Sprintf("can_buses[%d]", busIndex)
+		if len(bus.Nodes) == 0 {
+			continue
+		}
+
+		terms := canTerminations(bus, busIndex)
+		if len(terms) != 2 {
+			var parallel float64
+			for _, t := range terms {
+				parallel += 1 / t.Ohm
+			}
+			detail := "none fitted"
+			if parallel > 0 {
+				detail = fmt.Sprintf("%d fitted, %.0fΩ between CANH and CANL", len(terms), 1/parallel)
+			}
+			out = append(out, withLocation(locs, base, Finding{
+				Severity: SevError,
+				Code:     "CAN_TERMINATION",
+				Message: fmt.Sprintf(
+					"bus %s needs exactly two %.0fΩ terminations, one at each end (%s; expect 60Ω)",
+					bus.Name,
+					canTerminationOhm,
+					detail,
+				),
+			}))
+		}
+		for _, t := range terms {
+			if t.Ohm < 0.9*canTerminationOhm || t.Ohm > 1.1*canTerminationOhm {
+				out = append(out, withLocation(locs, t.Path, Finding{
+					Severity: SevWarn,
+					Code:     "CAN_TERMINATION_VALUE",
+					Message:  fmt.Sprintf("bus %s: %s termination %.0fΩ is not %.0fΩ", bus.Name, t.Label, t.Ohm, canTerminationOhm),
+				}))
+			}
+		}
+
+		ids := make(map[int][]string)
+		var order []int
+		for i, n := range bus.Nodes {
+			path := fmt.Sprintf("%s.nodes[%d]", base, i)
+			if n.NodeID != nil {
+				id := *n.NodeID
+				if id < 0 || (n.MaxNodeID > 0 && id > n.MaxNodeID) {
+					out = append(out, withLocation(locs, path+".node_id", Finding{
+						Severity: SevError,
+						Code:     "CAN_NODE_ID_RANGE",
+						Message:  fmt.Sprintf("%s node ID %d is outside 0..%d", n.Name, id, n.MaxNodeID),
+					}))
+				}
+				if _, ok := ids[id]; !ok {
+					order = append(order, id)
+				}
+				ids[id] = append(ids[id], n.Name)
+			}
+
+			if bus.BitrateKbps > 0 && len(n.SupportedBitratesKbps) > 0 && !containsInt(n.SupportedBitratesKbps, bus.BitrateKbps) {
+				out = append(out, withLocation(locs, base+".bitrate_kbps", Finding{
+					Severity: SevError,
+					Code:     "CAN_BITRATE_UNSUPPORTED",
+					Message: fmt.Sprintf(
+						"%s does not support %dkbps on bus %s (supported: %v)",
+						n.Name,
+						bus.BitrateKbps,
+						bus.Name,
+						n.SupportedBitratesKbps,
+					),
+				}))
+			}
+
+			minV, maxV := n.TransceiverSupplyMinV, n.TransceiverSupplyMaxV
+			if minV <= 0 && maxV <= 0 {
+				continue
+			}
+			rail, ok := logicSupply(spec, n.Rail)
+			if !ok || rail.VoltageV <= 0 {
+				continue
+			}
+			if (minV > 0 && rail.VoltageV < minV) || (maxV > 0 && rail.VoltageV > maxV) {
+				hint := "No rail in the power tree provides it; add a regulator or use a transceiver rated for the available rail."
+				if alt, ok := supplyWithin(spec, minV, maxV); ok {
+					hint = fmt.Sprintf("Power it from %s (%.2fV).", alt.Label(), alt.VoltageV)
+				}
+				out = append(out, withLocation(locs, path, Finding{
+					Severity: SevError,
+					Code:     "CAN_TRANSCEIVER_SUPPLY",
+					Message: fmt.Sprintf(
+						"%s transceiver needs %s but %s is %.2fV. %s",
+						n.Name,
+						voltageWindow(minV, maxV),
+						rail.Label(),
+						rail.VoltageV,
+						hint,
+					),
+				}))
+			}
+		}
+		for _, id := range order {
+			if names := ids[id]; len(names) > 1 {
+				out = append(out, withLocation(locs, base+".nodes", Finding{
+					Severity: SevError,
+					Code:     "CAN_NODE_ID_DUPLICATE",
+					Message:  fmt.Sprintf("Nodes %s share CAN node ID %d on bus %s", strings.Join(names, ", "), id, bus.Name),
+				}))
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,137 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/badimirzai/robotics-verifier-cli/internal/model"
+)
+
+func canNodeID(id int) *int { return &id }
+
+func canSpec() model.RobotSpec {
+	spec := baseSpec()
+	spec.MCU.LogicVoltageV = 3.3
+	spec.Power.Rail = model.Rail{VoltageV: 3.3, MaxCurrentA: 1}
+	spec.Driver.LogicVoltageMinV = 2.7
+	spec.CANBuses = []model.CANBus{{
+		Name:            "can0",
+		BitrateKbps:     500,
+		TerminationsOhm: []float64{120},
+		Nodes: []model.CANNode{
+			{
+				Name: "mcu", SupportedBitratesKbps: []int{125, 250, 500, 1000},
+				TransceiverSupplyMinV: 3.0, TransceiverSupplyMaxV: 3.6,
+			},
+			{Name: "odrive_left", NodeID: canNodeID(1), MaxNodeID: 63, SupportedBitratesKbps: []int{125, 250, 500, 1000}},
+			{
+				Name: "odrive_right", NodeID: canNodeID(2), MaxNodeID: 63, SupportedBitratesKbps: []int{125, 250, 500, 1000},
+				TerminationOhm: 120,
+			},
+		},
+	}}
+	return spec
+}
+
+func TestRuleCAN(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*model.RobotSpec)
+		want   []string
+		not    []string
+	}{
+		{
+			name:   "two_odrives_terminated_at_both_ends",
+			mutate: func(s *model.RobotSpec) {},
+			not: []string{
+				"CAN_TERMINATION", "CAN_TERMINATION_VALUE", "CAN_NODE_ID_DUPLICATE", "CAN_NODE_ID_RANGE",
+				"CAN_BITRATE_UNSUPPORTED", "CAN_TRANSCEIVER_SUPPLY",
+			},
+		},
+		{
+			name: "one_termination",
+			mutate: func(s *model.RobotSpec) {
+				s.CANBuses[0].Nodes[2].TerminationOhm = 0
+			},
+			want: []string{"CAN_TERMINATION"},
+		},
+		{
+			name: "every_node_terminated",
+			mutate: func(s *model.RobotSpec) {
+				s.CANBuses[0].Nodes[1].TerminationOhm = 120
+			},
+			want: []string{"CAN_TERMINATION"},
+		},
+		{
+			name: "wrong_resistor",
+			mutate: func(s *model.RobotSpec) {
+				s.CANBuses[0].TerminationsOhm = []float64{60}
+			},
+			want: []string{"CAN_TERMINATION_VALUE"},
+			not:  []string{"CAN_TERMINATION"},
+		},
+		{
+			name: "duplicate_node_id",
+			mutate: func(s *model.RobotSpec) {
+				s.CANBuses[0].Nodes[2].NodeID = canNodeID(1)
+			},
+			want: []string{"CAN_NODE_ID_DUPLICATE"},
+		},
+		{
+			name: "node_id_zero_is_valid",
+			mutate: func(s *model.RobotSpec) {
+				s.CANBuses[0].Nodes[1].NodeID = canNodeID(0)
+			},
+			not: []string{"CAN_NODE_ID_DUPLICATE", "CAN_NODE_ID_RANGE"},
+		},
+		{
+			name: "node_id_beyond_protocol",
+			mutate: func(s *model.RobotSpec) {
+				s.CANBuses[0].Nodes[1].NodeID = canNodeID(64)
+			},
+			want: []string{"CAN_NODE_ID_RANGE"},
+		},
+		{
+			name: "bitrate_not_supported_by_every_node",
+			mutate: func(s *model.RobotSpec) {
+				s.CANBuses[0].BitrateKbps = 800
+			},
+			want: []string{"CAN_BITRATE_UNSUPPORTED"},
+		},
+		{
+			name: "5v_transceiver_with_only_3v3",
+			mutate: func(s *model.RobotSpec) {
+				s.CANBuses[0].Nodes[0].TransceiverSupplyMinV = 4.5
+				s.CANBuses[0].Nodes[0].TransceiverSupplyMaxV = 5.5
+			},
+			want: []string{"CAN_TRANSCEIVER_SUPPLY"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := canSpec()
+			tt.mutate(&spec)
+			codes := reportCodes(RunAll(spec, nil))
+			for _, c := range tt.want {
+				requireHasCode(t, codes, c)
+			}
+			for _, c := range tt.not {
+				requireNoCode(t, codes, c)
+			}
+		})
+	}
+}
+
+func TestSupplyWithin(t *testing.T) {
+	spec := canSpec()
+	if _, ok := supplyWithin(spec, 4.5, 5.5); ok {
+		t.Fatalf("expected no 5V rail in a 3.3V only spec")
+	}
+	spec.Power.Rails = []model.PowerRail{
+		{Name: "periph_5v", Source: "battery", Regulator: "buck", VoltageV: 5, Efficiency: 0.9, MaxCurrentA: 1},
+	}
+	p, ok := supplyWithin(spec, 4.5, 5.5)
+	if !ok || p.Name != "periph_5v" {
+		t.Fatalf("expected periph_5v, got %+v (%v)", p, ok)
+	}
+}
@@ -0,0 +1,613 @@
+package validate
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/badimirzai/robotics-verifier-cli/internal/model"
+)
+
+// driverEntry is a motor driver together with the YAML path it was declared at.
+type driverEntry struct {
+	Driver model.MotorDriver
+	Path   string // "motor_driver" or "motor_drivers[i]"
+}
+
+// Label names the driver in finding messages.
+func (e driverEntry) Label() string {
+	if e.Driver.Name != "" {
+		return e.Driver.Name
+	}
+	return e.Path
+}
+
+// driverNoun names the driver in sentences; the singular shorthand keeps the plain wording.
+func driverNoun(e driverEntry) string {
+	if e.Path == "motor_driver" {
+		return "driver"
+	}
+	return "driver " + e.Label()
+}
+
+// driverEntries lists every motor driver in the spec. The singular motor_driver key is
+// shorthand and is kept whenever it is set or DC motors exist without a motor_drivers
+// list, so those specs still surface DRV_CHANNELS_INVALID.
+func driverEntries(spec model.RobotSpec) []driverEntry {
+	var out []driverEntry
+	if !reflect.ValueOf(spec.Driver).IsZero() || (len(spec.Drivers) == 0 && len(spec.Motors) > 0) {
+		out = append(out, driverEntry{Driver: spec.Driver, Path: "motor_driver"})
+	}
+	for i, d := range spec.Drivers {
+		out = append(out, driverEntry{Driver: d, Path: fmt.Sprintf("motor_drivers[%d]", i)})
+	}
+	return out
+}
+
+// motorDriverIndex returns the index into entries of the driver a motor is wired to.
+// Motors without a driver name belong to the only driver when there is exactly one.
+func motorDriverIndex(entries []driverEntry, m model.Motor) (int, bool) {
+	if m.Driver == "" {
+		if len(entries) == 1 {
+			return 0, true
+		}
+		return -1, false
+	}
+	for i, e := range entries {
+		if e.Driver.Name == m.Driver {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// defaultParallelDerating is the usable fraction of summed channel ratings when
+// channels are paralleled and the driver does not declare its own.
+const defaultParallelDerating = 0.8
+
+// parallelDerating returns the driver's parallel derating, or the default when unset.
+func parallelDerating(d model.MotorDriver) float64 {
+	if d.ParallelDerating > 0 {
+		return d.ParallelDerating
+	}
+	return defaultParallelDerating
+}
+
+// motorInstance is one physical motor from a motors[] entry.
+type motorInstance struct {
+	MotorIndex int
+	Instance   int // 1-based
+	Motor      model.Motor
+}
+
+// instanceWiring is where one motor instance is connected: the index into
+// driverEntries (-1 when unresolved) and its channels (nil for auto-assignment).
+type instanceWiring struct {
+	motorInstance
+	Driver   int
+	Channels []int
+}
+
+// findMotor returns the index of the motors[] entry with the given name and whether
+// the name is unique.
+func findMotor(spec model.RobotSpec, name string) (int, bool) {
+	idx := -1
+	for i, m := range spec.Motors {
+		if m.Name != name {
+			continue
+		}
+		if idx >= 0 {
+			return idx, false
+		}
+		idx = i
+	}
+	return idx, idx >= 0
+}
+
+// wiringInstance returns the 1-based instance a wiring entry refers to. Instance
+// may be omitted for motors with count 1.
+func wiringInstance(w model.Wiring) int {
+	if w.Instance == 0 {
+		return 1
+	}
+	return w.Instance
+}
+
+// wiringDriverIndex resolves the driver of a wiring entry, falling back to the
+// motor's own driver.
+func wiringDriverIndex(entries []driverEntry, w model.Wiring, m model.Motor) (int, bool) {
+	if w.Driver == "" {
+		return motorDriverIndex(entries, m)
+	}
+	for i, e := range entries {
+		if e.Driver.Name == w.Driver {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// usableWiring returns the wiring entries that can be applied, keyed by motor index
+// and instance. Entries with problems are left out here and reported by ruleWiring;
+// for duplicates the first entry wins.
+func usableWiring(spec model.RobotSpec, entries []driverEntry) map[[2]int]model.Wiring {
+	out := make(map[[2]int]model.Wiring)
+	for _, w := range spec.Wiring {
+		mIdx, ok := findMotor(spec, w.Motor)
+		if !ok {
+			continue
+		}
+		m := spec.Motors[mIdx]
+		inst := wiringInstance(w)
+		if inst < 1 || inst > m.Count || len(positiveChannels(w.Channels)) == 0 {
+			continue
+		}
+		if _, ok := wiringDriverIndex(entries, w, m); !ok {
+			continue
+		}
+		key := [2]int{mIdx, inst}
+		if _, dup := out[key]; !dup {
+			out[key] = w
+		}
+	}
+	return out
+}
+
+// positiveChannels returns the sorted, de-duplicated channel numbers above zero.
+func positiveChannels(channels []int) []int {
+	var out []int
+	seen := make(map[int]bool)
+	for _, ch := range channels {
+		if ch > 0 && !seen[ch] {
+			seen[ch] = true
+			out = append(out, ch)
+		}
+	}
+	sort.Ints(out)
+	return out
+}
+
+// wireInstances resolves the driver and channels of every motor instance. A wiring
+// entry overrides motors[].driver and motors[].channels for its instance.
+func wireInstances(spec model.RobotSpec, entries []driverEntry) []instanceWiring {
+	wiring := usableWiring(spec, entries)
+	var out []instanceWiring
+	for mIdx, m := range spec.Motors {
+		for k := 0; k < m.Count; k++ {
+			iw := instanceWiring{
+				motorInstance: motorInstance{MotorIndex: mIdx, Instance: k + 1, Motor: m},
+				Driver:        -1,
+			}
+			if w, ok := wiring[[2]int{mIdx, k + 1}]; ok {
+				iw.Driver, _ = wiringDriverIndex(entries, w, m)
+				iw.Channels = positiveChannels(w.Channels)
+				out = append(out, iw)
+				continue
+			}
+			if idx, ok := motorDriverIndex(entries, m); ok {
+				iw.Driver = idx
+			}
+			if k < len(m.Channels) && m.Channels[k] > 0 {
+				iw.Channels = []int{m.Channels[k]}
+			}
+			out = append(out, iw)
+		}
+	}
+	return out
+}
+
+// channelLoad is a group of driver channels wired together and the motor instances
+// it drives. More than one channel means the outputs are paralleled.
+type channelLoad struct {
+	Channels []int // 1-based, sorted
+	Motors   []motorInstance
+}
+
+func (c channelLoad) paralleled() bool {
+	return len(c.Channels) > 1
+}
+
+// name is "channel 1" or "channels 1+2".
+func (c channelLoad) name() string {
+	if !c.paralleled() {
+		return fmt.Sprintf("channel %d", c.Channels[0])
+	}
+	parts := make([]string, len(c.Channels))
+	for i, ch := range c.Channels {
+		parts[i] = fmt.Sprint(ch)
+	}
+	return "channels " + strings.Join(parts, "+")
+}
+
+// ratingA scales a per-channel rating to the group, derated when paralleled.
+func (c channelLoad) ratingA(perChannelA float64, d model.MotorDriver) float64 {
+	if !c.paralleled() {
+		return perChannelA
+	}
+	return perChannelA * float64(len(c.Channels)) * parallelDerating(d)
+}
+
+// stallA is the group stall current. When every motor declares the voltage its stall
+// current is rated at, it is recomputed from the winding resistance and the voltage
+// left at the terminals after the bridge drop; otherwise the datasheet values add up.
+func (c channelLoad) stallA(d model.MotorDriver, supplyV float64) float64 {
+	total := 0.0
+	conductance := 0.0 // parallel windings, 1/Ω
+	rated := supplyV > 0
+	for _, mi := range c.Motors {
+		m := mi.Motor
+		total += m.StallCurrentA
+		if m.RatedVoltageV <= 0 || m.StallCurrentA <= 0 {
+			rated = false
+			continue
+		}
+		conductance += m.StallCurrentA / m.RatedVoltageV
+	}
+	if !rated {
+		return total
+	}
+	fixedV, ohms := bridgeDrop(d)
+	return math.Max(supplyV-fixedV, 0) / (1/conductance + ohms/float64(len(c.Channels)))
+}
+
+func (c channelLoad) nominalA() float64 {
+	total := 0.0
+	for _, mi := range c.Motors {
+		total += mi.Motor.NominalCurrentA
+	}
+	return total
+}
+
+// label names the motors on the channel, e.g. "motor M" or "motors A, B".
+func (c channelLoad) label() string {
+	names := make([]string, 0, len(c.Motors))
+	seen := make(map[string]bool)
+	for _, mi := range c.Motors {
+		if seen[mi.Motor.Name] {
+			continue
+		}
+		seen[mi.Motor.Name] = true
+		names = append(names, mi.Motor.Name)
+	}
+	if len(c.Motors) == 1 {
+		return "motor " + names[0]
+	}
+	return fmt.Sprintf("%d motors (%s)", len(c.Motors), strings.Join(names, ", "))
+}
+
+// driverAllocation is the motor wiring of one driver.
+type driverAllocation struct {
+	Entry    driverEntry
+	Channels []channelLoad // channel groups in use, sorted by first channel
+}
+
+func (a driverAllocation) instances() int {
+	n := 0
+	for _, ch := range a.Channels {
+		n += len(ch.Motors)
+	}
+	return n
+}
+
+// channelsUsed counts the distinct driver channels across all groups.
+func (a driverAllocation) channelsUsed() int {
+	seen := make(map[int]bool)
+	for _, g := range a.Channels {
+		for _, ch := range g.Channels {
+			seen[ch] = true
+		}
+	}
+	return len(seen)
+}
+
+func (a driverAllocation) stallA(spec model.RobotSpec) float64 {
+	supplyV := a.supplyV(spec)
+	total := 0.0
+	for _, ch := range a.Channels {
+		total += ch.stallA(a.Entry.Driver, supplyV)
+	}
+	return total
+}
+
+// supplyV is the driver motor supply voltage, 0 when unresolved.
+func (a driverAllocation) supplyV(spec model.RobotSpec) float64 {
+	supply, ok := motorSupply(spec, a.Entry.Driver.SupplyRail)
+	if !ok {
+		return 0
+	}
+	return supply.VoltageV
+}
+
+func (a driverAllocation) nominalA() float64 {
+	total := 0.0
+	for _, ch := range a.Channels {
+		total += ch.nominalA()
+	}
+	return total
+}
+
+// allocateDrivers groups every motor instance onto driver channels. Instances wired to
+// the same channel set share one group; the rest take the lowest free channel numbers,
+// which may run past the driver's channel count so DRV_CHANNELS_INSUFFICIENT can report
+// the need. Motors with an unknown or ambiguous driver are skipped and reported by
+// ruleMotorDriverAssignment.
+func allocateDrivers(spec model.RobotSpec) []driverAllocation {
+	entries := driverEntries(spec)
+	groups := make([]map[string]*channelLoad, len(entries))
+	used := make([]map[int]bool, len(entries))
+	pending := make([][]motorInstance, len(entries))
+	for i := range entries {
+		groups[i] = make(map[string]*channelLoad)
+		used[i] = make(map[int]bool)
+	}
+
+	for _, iw := range wireInstances(spec, entries) {
+		if iw.Driver < 0 {
+			continue
+		}
+		if len(iw.Channels) == 0 {
+			pending[iw.Driver] = append(pending[iw.Driver], iw.motorInstance)
+			continue
+		}
+		key := fmt.Sprint(iw.Channels)
+		g, ok := groups[iw.Driver][key]
+		if !ok {
+			g = &channelLoad{Channels: iw.Channels}
+			groups[iw.Driver][key] = g
+		}
+		g.Motors = append(g.Motors, iw.motorInstance)
+		for _, ch := range iw.Channels {
+			used[iw.Driver][ch] = true
+		}
+	}
+
+	out := make([]driverAllocation, len(entries))
+	for i, e := range entries {
+		next := 1
+		for _, mi := range pending[i] {
+			for used[i][next] {
+				next++
+			}
+			used[i][next] = true
+			groups[i][fmt.Sprint([]int{next})] = &channelLoad{Channels: []int{next}, Motors: []motorInstance{mi}}
+		}
+		channels := make([]channelLoad, 0, len(groups[i]))
+		for _, g := range groups[i] {
+			channels = append(channels, *g)
+		}
+		sort.Slice(channels, func(a, b int) bool {
+			ca, cb := channels[a].Channels, channels[b].Channels
+			if ca[0] != cb[0] {
+				return ca[0] < cb[0]
+			}
+			return len(ca) < len(cb)
+		})
+		out[i] = driverAllocation{Entry: e, Channels: channels}
+	}
+	return out
+}
+
+// driverSupplyName returns the supply a driver's motor side draws from.
+func driverSupplyName(d model.MotorDriver) string {
+	if d.SupplyRail == "" {
+		return batterySource
+	}
+	return d.SupplyRail
+}
+
+func ruleMotorDriverAssignment(spec model.RobotSpec, locs map[string]Location) []Finding {
+	entries := driverEntries(spec)
+	var out []Finding
+
+	if len(entries) > 1 {
+		seen := make(map[string]bool)
+		for _, e := range entries {
+			if e.Driver.Name == "" {
+				out = append(out, withLocation(locs, e.Path, Finding{
+					Severity: SevError,
+					Code:     "DRV_NAME_MISSING",
+					Message:  fmt.Sprintf("%s.name must be set when more than one motor driver is declared", e.Path),
+				}))
+				continue
+			}
+			if seen[e.Driver.Name] {
+				out = append(out, withLocation(locs, e.Path+".name", Finding{
+					Severity: SevError,
+					Code:     "DRV_NAME_DUPLICATE",
+					Message:  fmt.Sprintf("motor driver name %q is used more than once", e.Driver.Name),
+				}))
+			}
+			seen[e.Driver.Name] = true
+		}
+	}
+
+	unresolved := make(map[int]bool)
+	for _, iw := range wireInstances(spec, entries) {
+		if iw.Driver < 0 {
+			unresolved[iw.MotorIndex] = true
+		}
+	}
+
+	for i, m := range spec.Motors {
+		base := fmt.Sprintf("motors[%d]", i)
+		if unresolved[i] {
+			if m.Driver == "" {
+				out = append(out, withLocation(locs, base, Finding{
+					Severity: SevError,
+					Code:     "MOTOR_DRIVER_UNASSIGNED",
+					Message:  fmt.Sprintf("motor %s must name its driver when more than one motor driver is declared", m.Name),
+				}))
+			} else {
+				out = append(out, withLocation(locs, base+".driver", Finding{
+					Severity: SevError,
+					Code:     "MOTOR_DRIVER_UNKNOWN",
+					Message:  fmt.Sprintf("motor %s references unknown driver %q", m.Name, m.Driver),
+				}))
+			}
+			continue
+		}
+		if len(m.Channels) == 0 {
+			continue
+		}
+		if len(m.Channels) != m.Count {
+			out = append(out, withLocation(locs, base+".channels", Finding{
+				Severity: SevError,
+				Code:     "MOTOR_CHANNELS_COUNT_MISMATCH",
+				Message:  fmt.Sprintf("motor %s lists %d channel(s) for count %d", m.Name, len(m.Channels), m.Count),
+			}))
+		}
+		dIdx, ok := motorDriverIndex(entries, m)
+		if !ok {
+			continue
+		}
+		out = append(out, channelRangeFindings(entries[dIdx], "motor "+m.Name, base+".channels", m.Channels, locs)...)
+	}
+
+	for _, alloc := range allocateDrivers(spec) {
+		out = append(out, channelGroupFindings(alloc, locs)...)
+	}
+	return out
+}
+
+// channelRangeFindings reports channel numbers outside the driver's channel count.
+func channelRangeFindings(e driverEntry, owner, path string, channels []int, locs map[string]Location) []Finding {
+	var out []Finding
+	for k, ch := range channels {
+		if ch >= 1 && (e.Driver.Channels <= 0 || ch <= e.Driver.Channels) {
+			continue
+		}
+		out = append(out, withLocation(locs, fmt.Sprintf("%s[%d]", path, k), Finding{
+			Severity: SevError,
+			Code:     "DRV_CHANNEL_OUT_OF_RANGE",
+			Message:  fmt.Sprintf("%s channel %d is outside %s channels 1..%d", owner, ch, e.Label(), e.Driver.Channels),
+		}))
+	}
+	return out
+}
+
+// channelGroupFindings describes shared and paralleled channel groups and rejects
+// groups that reuse a channel already wired into a different group.
+func channelGroupFindings(alloc driverAllocation, locs map[string]Location) []Finding {
+	path := alloc.Entry.Path + ".channels"
+	drv := alloc.Entry.Driver
+	var out []Finding
+	owner := make(map[int]int)
+	for gi, g := range alloc.Channels {
+		for _, ch := range g.Channels {
+			prev, taken := owner[ch]
+			if !taken {
+				owner[ch] = gi
+				continue
+			}
+			if prev == gi {
+				continue
+			}
+			other := alloc.Channels[prev]
+			out = append(out, withLocation(locs, path, Finding{
+				Severity: SevError,
+				Code:     "DRV_CHANNEL_OVERLAP",
+				Message: fmt.Sprintf(
+					"%s channel %d is wired to %s (%s) and %s (%s); paralleled channels must be wired identically",
+					alloc.Entry.Label(), ch, other.name(), other.label(), g.name(), g.label(),
+				),
+			}))
+		}
+		if g.paralleled() {
+			out = append(out, withLocation(locs, path, Finding{
+				Severity: SevInfo,
+				Code:     "DRV_CHANNELS_PARALLEL",
+				Message: fmt.Sprintf(
+					"%s %s paralleled for %s: %.2fA peak, %.2fA continuous after %.0f%% current sharing derating",
+					alloc.Entry.Label(), g.name(), g.label(),
+					g.ratingA(drv.PeakPerChA, drv), g.ratingA(drv.ContinuousPerChA, drv),
+					parallelDerating(drv)*100,
+				),
+			}))
+		}
+		if len(g.Motors) >= 2 {
+			out = append(out, withLocation(locs, path, Finding{
+				Severity: SevInfo,
+				Code:     "DRV_CHANNEL_SHARED",
+				Message: fmt.Sprintf(
+					"%s %s drives %s, headroom checks use the combined load",
+					alloc.Entry.Label(), g.name(), g.label(),
+				),
+			}))
+		}
+	}
+	return out
+}
+
+// ruleWiring validates the wiring section: each entry must name a unique motor
+// instance, a known driver and at least one channel within range.
+func ruleWiring(spec model.RobotSpec, locs map[string]Location) []Finding {
+	entries := driverEntries(spec)
+	var out []Finding
+	seen := make(map[[2]int]bool)
+	for i, w := range spec.Wiring {
+		base := fmt.Sprintf("wiring[%d]", i)
+		mIdx, unique := findMotor(spec, w.Motor)
+		if mIdx < 0 {
+			out = append(out, withLocation(locs, base+".motor", Finding{
+				Severity: SevError,
+				Code:     "WIRING_MOTOR_UNKNOWN",
+				Message:  fmt.Sprintf("%s references unknown motor %q", base, w.Motor),
+			}))
+			continue
+		}
+		if !unique {
+			out = append(out, withLocation(locs, base+".motor", Finding{
+				Severity: SevError,
+				Code:     "WIRING_MOTOR_AMBIGUOUS",
+				Message:  fmt.Sprintf("%s references motor %q, which names more than one motors entry", base, w.Motor),
+			}))
+			continue
+		}
+		m := spec.Motors[mIdx]
+		inst := wiringInstance(w)
+		if inst < 1 || inst > m.Count {
+			out = append(out, withLocation(locs, base+".instance", Finding{
+				Severity: SevError,
+				Code:     "WIRING_INSTANCE_RANGE",
+				Message:  fmt.Sprintf("%s instance %d is outside motor %s instances 1..%d", base, inst, m.Name, m.Count),
+			}))
+			continue
+		}
+		key := [2]int{mIdx, inst}
+		if seen[key] {
+			out = append(out, withLocation(locs, base, Finding{
+				Severity: SevError,
+				Code:     "WIRING_DUPLICATE",
+				Message:  fmt.Sprintf("motor %s instance %d is wired more than once", m.Name, inst),
+			}))
+			continue
+		}
+		seen[key] = true
+		dIdx, ok := wiringDriverIndex(entries, w, m)
+		if !ok {
+			name := w.Driver
+			if name == "" {
+				name = m.Driver
+			}
+			out = append(out, withLocation(locs, base+".driver", Finding{
+				Severity: SevError,
+				Code:     "WIRING_DRIVER_UNKNOWN",
+				Message:  fmt.Sprintf("%s cannot resolve driver %q for motor %s", base, name, m.Name),
+			}))
+			continue
+		}
+		if len(w.Channels) == 0 {
+			out = append(out, withLocation(locs, base+".channels", Finding{
+				Severity: SevError,
+				Code:     "WIRING_CHANNELS_MISSING",
+				Message:  fmt.Sprintf("%s must list at least one channel for motor %s instance %d", base, m.Name, inst),
+			}))
+			continue
+		}
+		owner := fmt.Sprintf("motor %s instance %d", m.Name, inst)
+		out = append(out, channelRangeFindings(entries[dIdx], owner, base+".channels", w.Channels, locs)...)
+	}
+	return out
+}
@@ -0,0 +1,291 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/badimirzai/robotics-verifier-cli/internal/model"
+)
+
+func twoDriverSpec() model.RobotSpec {
+	spec := baseSpec()
+	front := spec.Driver
+	front.Name = "front"
+	rear := spec.Driver
+	rear.Name = "rear"
+	spec.Driver = model.MotorDriver{}
+	spec.Drivers = []model.MotorDriver{front, rear}
+	spec.Motors = []model.Motor{
+		{Name: "front_wheels", Driver: "front", Count: 2, NominalCurrentA: 1, StallCurrentA: 5},
+		{Name: "rear_wheels", Driver: "rear", Count: 2, NominalCurrentA: 1, StallCurrentA: 5},
+	}
+	return spec
+}
+
+func TestRuleMultipleDrivers(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*model.RobotSpec)
+		want   []string
+		not    []string
+	}{
+		{
+			name:   "four_motors_two_drivers",
+			mutate: func(s *model.RobotSpec) {},
+			want:   []string{"DRV_CHANNELS_OK"},
+			not: []string{
+				"DRV_CHANNELS_INSUFFICIENT", "DRV_CHANNELS_INVALID", "DRV_PEAK_OVERLOAD",
+				"MOTOR_DRIVER_UNASSIGNED", "MOTOR_DRIVER_UNKNOWN",
+			},
+		},
+		{
+			name: "all_motors_on_one_driver",
+			mutate: func(s *model.RobotSpec) {
+				s.Motors[1].Driver = "front"
+			},
+			want: []string{"DRV_CHANNELS_INSUFFICIENT", "DRV_PEAK_OVERLOAD"},
+		},
+		{
+			name: "motor_without_driver",
+			mutate: func(s *model.RobotSpec) {
+				s.Motors[0].Driver = ""
+			},
+			want: []string{"MOTOR_DRIVER_UNASSIGNED"},
+		},
+		{
+			name: "motor_unknown_driver",
+			mutate: func(s *model.RobotSpec) {
+				s.Motors[0].Driver = "middle"
+			},
+			want: []string{"MOTOR_DRIVER_UNKNOWN"},
+		},
+		{
+			name: "duplicate_driver_names",
+			mutate: func(s *model.RobotSpec) {
+				s.Drivers[1].Name = "front"
+			},
+			want: []string{"DRV_NAME_DUPLICATE"},
+		},
+		{
+			name: "one_driver_out_of_supply_range",
+			mutate: func(s *model.RobotSpec) {
+				s.Drivers[1].MotorSupplyMaxV = 10
+			},
+			want: []string{"DRV_SUPPLY_RANGE"},
+		},
+		{
+			name: "one_driver_peak_below_stall",
+			mutate: func(s *model.RobotSpec) {
+				s.Drivers[1].PeakPerChA = 3
+			},
+			want: []string{"DRV_PEAK_LT_STALL"},
+		},
+		{
+			name: "singular_shorthand_with_list",
+			mutate: func(s *model.RobotSpec) {
+				s.Driver = s.Drivers[0]
+				s.Drivers = s.Drivers[1:]
+			},
+			want: []string{"DRV_CHANNELS_OK"},
+			not:  []string{"DRV_CHANNELS_INVALID", "MOTOR_DRIVER_UNKNOWN"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := twoDriverSpec()
+			tt.mutate(&spec)
+			codes := reportCodes(RunAll(spec, nil))
+			for _, c := range tt.want {
+				requireHasCode(t, codes, c)
+			}
+			for _, c := range tt.not {
+				requireNoCode(t, codes, c)
+			}
+		})
+	}
+}
+
+func TestRuleMotorChannels(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*model.RobotSpec)
+		want   []string
+		not    []string
+	}{
+		{
+			name: "explicit_channels",
+			mutate: func(s *model.RobotSpec) {
+				s.Motors[0].Channels = []int{2, 1}
+			},
+			not: []string{"DRV_CHANNEL_OUT_OF_RANGE", "MOTOR_CHANNELS_COUNT_MISMATCH", "DRV_CHANNEL_SHARED"},
+		},
+		{
+			name: "channel_out_of_range",
+			mutate: func(s *model.RobotSpec) {
+				s.Motors[0].Channels = []int{1, 3}
+			},
+			want: []string{"DRV_CHANNEL_OUT_OF_RANGE"},
+		},
+		{
+			name: "channels_count_mismatch",
+			mutate: func(s *model.RobotSpec) {
+				s.Motors[0].Channels = []int{1}
+			},
+			want: []string{"MOTOR_CHANNELS_COUNT_MISMATCH"},
+		},
+		{
+			name: "shared_channel_combines_load",
+			mutate: func(s *model.RobotSpec) {
+				s.Motors[0].Channels = []int{1, 1}
+			},
+			want: []string{"DRV_CHANNEL_SHARED", "DRV_PEAK_LT_STALL"},
+			not:  []string{"DRV_CHANNELS_INSUFFICIENT"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := baseSpec()
+			tt.mutate(&spec)
+			codes := reportCodes(RunAll(spec, nil))
+			for _, c := range tt.want {
+				requireHasCode(t, codes, c)
+			}
+			for _, c := range tt.not {
+				requireNoCode(t, codes, c)
+			}
+		})
+	}
+}
+
+func TestRuleWiring(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*model.RobotSpec)
+		want   []string
+		not    []string
+	}{
+		{
+			name:   "big_motor_on_one_channel",
+			mutate: func(s *model.RobotSpec) {},
+			want:   []string{"DRV_PEAK_LT_STALL"},
+		},
+		{
+			name: "paralleled_channels_share_stall",
+			mutate: func(s *model.RobotSpec) {
+				s.Wiring = []model.Wiring{{Motor: "Big", Channels: []int{1, 2}}}
+			},
+			want: []string{"DRV_CHANNELS_PARALLEL", "DRV_CHANNELS_OK"},
+			not:  []string{"DRV_PEAK_LT_STALL", "DRV_CONT_LOW_MARGIN", "DRV_CHANNEL_OVERLAP"},
+		},
+		{
+			name: "parallel_derating_too_low",
+			mutate: func(s *model.RobotSpec) {
+				s.Driver.ParallelDerating = 0.6
+				s.Wiring = []model.Wiring{{Motor: "Big", Channels: []int{1, 2}}}
+			},
+			want: []string{"DRV_PEAK_LT_STALL"},
+		},
+		{
+			name: "paralleled_group_overlaps_other_motor",
+			mutate: func(s *model.RobotSpec) {
+				s.Motors = append(s.Motors, model.Motor{Name: "Small", Count: 1, NominalCurrentA: 0.2, StallCurrentA: 1})
+				s.Wiring = []model.Wiring{
+					{Motor: "Big", Channels: []int{1, 2}},
+					{Motor: "Small", Channels: []int{2}},
+				}
+			},
+			want: []string{"DRV_CHANNEL_OVERLAP"},
+		},
+		{
+			name: "two_small_motors_on_one_channel",
+			mutate: func(s *model.RobotSpec) {
+				s.Motors = []model.Motor{{Name: "Small", Count: 2, NominalCurrentA: 0.2, StallCurrentA: 1}}
+				s.Wiring = []model.Wiring{
+					{Motor: "Small", Instance: 1, Channels: []int{1}},
+					{Motor: "Small", Instance: 2, Channels: []int{1}},
+				}
+			},
+			want: []string{"DRV_CHANNEL_SHARED"},
+			not:  []string{"DRV_PEAK_LT_STALL", "DRV_CHANNEL_OVERLAP"},
+		},
+		{
+			name: "unknown_motor",
+			mutate: func(s *model.RobotSpec) {
+				s.Wiring = []model.Wiring{{Motor: "Huge", Channels: []int{1}}}
+			},
+			want: []string{"WIRING_MOTOR_UNKNOWN"},
+		},
+		{
+			name: "instance_out_of_range",
+			mutate: func(s *model.RobotSpec) {
+				s.Wiring = []model.Wiring{{Motor: "Big", Instance: 2, Channels: []int{1}}}
+			},
+			want: []string{"WIRING_INSTANCE_RANGE"},
+		},
+		{
+			name: "instance_wired_twice",
+			mutate: func(s *model.RobotSpec) {
+				s.Wiring = []model.Wiring{
+					{Motor: "Big", Channels: []int{1}},
+					{Motor: "Big", Instance: 1, Channels: []int{2}},
+				}
+			},
+			want: []string{"WIRING_DUPLICATE"},
+		},
+		{
+			name: "unknown_driver",
+			mutate: func(s *model.RobotSpec) {
+				s.Wiring = []model.Wiring{{Motor: "Big", Driver: "rear", Channels: []int{1}}}
+			},
+			want: []string{"WIRING_DRIVER_UNKNOWN"},
+		},
+		{
+			name: "channel_out_of_range",
+			mutate: func(s *model.RobotSpec) {
+				s.Wiring = []model.Wiring{{Motor: "Big", Channels: []int{2, 3}}}
+			},
+			want: []string{"DRV_CHANNEL_OUT_OF_RANGE"},
+		},
+		{
+			name: "missing_channels",
+			mutate: func(s *model.RobotSpec) {
+				s.Wiring = []model.Wiring{{Motor: "Big"}}
+			},
+			want: []string{"WIRING_CHANNELS_MISSING"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := baseSpec()
+			spec.Motors = []model.Motor{{Name: "Big", Count: 1, NominalCurrentA: 2, StallCurrentA: 8}}
+			tt.mutate(&spec)
+			codes := reportCodes(RunAll(spec, nil))
+			for _, c := range tt.want {
+				requireHasCode(t, codes, c)
+			}
+			for _, c := range tt.not {
+				requireNoCode(t, codes, c)
+			}
+		})
+	}
+}
+
+func TestWiringOverridesMotorDriver(t *testing.T) {
+	spec := twoDriverSpec()
+	spec.Wiring = []model.Wiring{{Motor: "front_wheels", Instance: 2, Driver: "rear", Channels: []int{1}}}
+	spec.Motors[1].Count = 1
+
+	allocs := allocateDrivers(spec)
+	if got := allocs[0].instances(); got != 1 {
+		t.Fatalf("expected 1 instance on front, got %d", got)
+	}
+	if got := allocs[1].instances(); got != 2 {
+		t.Fatalf("expected 2 instances on rear, got %d", got)
+	}
+	// rear_wheels auto-assigns around the wired channel.
+	if got := allocs[1].Channels[1].Channels; len(got) != 1 || got[0] != 2 {
+		t.Fatalf("expected rear_wheels on channel 2, got %v", got)
+	}
+}
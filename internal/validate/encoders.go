@@ -0,0 +1,193 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/badimirzai/robotics-verifier-cli/internal/model"
+)
+
+// defaultDecoding counts every edge on both channels (x4 quadrature decoding).
+const defaultDecoding = 4
+
+func encoderDecoding(e model.Encoder) int {
+	if e.Decoding == 0 {
+		return defaultDecoding
+	}
+	return e.Decoding
+}
+
+// encoderEdgeHz is the interrupt rate one encoder produces with its motor at no load
+// and rated voltage, the fastest it normally turns.
+func encoderEdgeHz(e model.Encoder, m model.Motor) float64 {
+	gear := m.GearRatio
+	if gear <= 0 {
+		gear = 1
+	}
+	return m.NoLoadRPM * gear / 60 * e.PPR * float64(encoderDecoding(e))
+}
+
+// encoderHighV is the high level an encoder drives into the MCU: its supply for
+// push-pull outputs and the pull-up voltage for open collector ones. It is 0 when the
+// output type or the supply is unknown.
+func encoderHighV(spec model.RobotSpec, e model.Encoder) (float64, string) {
+	switch strings.ToLower(strings.TrimSpace(e.Output)) {
+	case "push_pull":
+		if rail, ok := logicSupply(spec, e.Rail); ok && rail.VoltageV > 0 {
+			return rail.VoltageV, "push-pull from " + rail.Label()
+		}
+	case "open_collector":
+		if e.PullupV > 0 {
+			return e.PullupV, "open collector pulled up"
+		}
+		return spec.MCU.LogicVoltageV, "open collector on MCU pull-ups"
+	}
+	return 0, ""
+}
+
+func ruleEncoders(spec model.RobotSpec, locs map[string]Location) []Finding {
+	if len(spec.Encoders) == 0 {
+		return nil
+	}
+	var out []Finding
+	mcu := spec.MCU
+	var totalHz float64
+	var rated []string
+
+	for i, e := range spec.Encoders {
+		base := fmt.Sprintf("encoders[%d]", i)
+		idx, _ := findMotor(spec, e.Motor)
+		if idx < 0 {
+			out = append(out, withLocation(locs, base+".motor", Finding{
+				Severity: SevError,
+				Code:     "ENCODER_MOTOR_UNKNOWN",
+				Message:  fmt.Sprintf("encoder %s references unknown motor %q", e.Name, e.Motor),
+			}))
+		}
+
+		if d := encoderDecoding(e); d != 1 && d != 2 && d != 4 {
+			out = append(out, withLocation(locs, base+".decoding", Finding{
+				Severity: SevError,
+				Code:     "ENCODER_DECODING_INVALID",
+				Message:  fmt.Sprintf("encoder %s decoding %d must be 1, 2 or 4", e.Name, d),
+			}))
+		}
+
+		rail, haveRail := logicSupply(spec, e.Rail)
+		haveRail = haveRail && rail.VoltageV > 0
+		if haveRail && ((e.SupplyMinV > 0 && rail.VoltageV < e.SupplyMinV) || (e.SupplyMaxV > 0 && rail.VoltageV > e.SupplyMaxV)) {
+			out = append(out, withLocation(locs, base, Finding{
+				Severity: SevError,
+				Code:     "ENCODER_SUPPLY_RANGE",
+				Message: fmt.Sprintf(
+					"encoder %s needs %s but %s is %.2fV",
+					e.Name,
+					voltageWindow(e.SupplyMinV, e.SupplyMaxV),
+					rail.Label(),
+					rail.VoltageV,
+				),
+			}))
+		}
+
+		switch strings.ToLower(strings.TrimSpace(e.Output)) {
+		case "", "push_pull", "open_collector":
+		default:
+			out = append(out, withLocation(locs, base+".output", Finding{
+				Severity: SevError,
+				Code:     "ENCODER_OUTPUT_INVALID",
+				Message:  fmt.Sprintf("encoder %s output %q must be push_pull or open_collector", e.Name, e.Output),
+			}))
+		}
+		highV, source := encoderHighV(spec, e)
+		if highV > 0 && mcu.LogicVoltageV > 0 {
+			overdrive := !mcuFiveVTolerant(mcu) && highV > mcu.LogicVoltageV+ioOverdriveV
+			r, routed, works := shiftStatus(spec, e.Name)
+			switch {
+			case overdrive && routed && works:
+				out = append(out, withLocation(locs, r.Path, Finding{
+					Severity: SevInfo,
+					Code:     "LOGIC_LEVEL_SHIFTED",
+					Message: fmt.Sprintf(
+						"encoder %s outputs %.2fV (%s) above %.2fV MCU logic, mismatch resolved via shifter %s",
+						e.Name,
+						highV,
+						source,
+						mcu.LogicVoltageV,
+						r.Shifter.Name,
+					),
+				}))
+			case overdrive:
+				out = append(out, withLocation(locs, base, Finding{
+					Severity: SevError,
+					Code:     "ENCODER_LEVEL_MISMATCH",
+					Message: fmt.Sprintf(
+						"encoder %s outputs %.2fV (%s) into MCU pins rated for %.2fV logic. Power it from the logic rail, pull up to %.2fV, or add a level shifter.",
+						e.Name,
+						highV,
+						source,
+						mcu.LogicVoltageV,
+						mcu.LogicVoltageV,
+					),
+				}))
+			case highV < 0.7*mcu.LogicVoltageV:
+				out = append(out, withLocation(locs, base, Finding{
+					Severity: SevWarn,
+					Code:     "ENCODER_LEVEL_LOW",
+					Message: fmt.Sprintf(
+						"encoder %s high level %.2fV (%s) is below 70%% of the %.2fV MCU logic and may not read as high",
+						e.Name,
+						highV,
+						source,
+						mcu.LogicVoltageV,
+					),
+				}))
+			}
+		}
+
+		if idx >= 0 && spec.Motors[idx].NoLoadRPM > 0 && e.PPR > 0 {
+			hz := encoderEdgeHz(e, spec.Motors[idx])
+			totalHz += hz
+			rated = append(rated, fmt.Sprintf("%s %.0f/s", e.Name, hz))
+		}
+	}
+
+	if mcu.MaxInterruptRateHz > 0 && totalHz > 0 {
+		detail := fmt.Sprintf("%s at no load rpm", strings.Join(rated, ", "))
+		switch {
+		case totalHz > mcu.MaxInterruptRateHz:
+			out = append(out, withLocation(locs, "encoders", Finding{
+				Severity: SevError,
+				Code:     "ENCODER_RATE_OVER",
+				Message: fmt.Sprintf(
+					"encoders produce %.0f edges/s, above the MCU interrupt budget of %.0f/s (%s). Lower the decoding or use a hardware counter.",
+					totalHz,
+					mcu.MaxInterruptRateHz,
+					detail,
+				),
+			}))
+		case totalHz >= 0.8*mcu.MaxInterruptRateHz:
+			out = append(out, withLocation(locs, "encoders", Finding{
+				Severity: SevWarn,
+				Code:     "ENCODER_RATE_MARGIN_LOW",
+				Message: fmt.Sprintf(
+					"encoders produce %.0f edges/s, close to the MCU interrupt budget of %.0f/s (%s)",
+					totalHz,
+					mcu.MaxInterruptRateHz,
+					detail,
+				),
+			}))
+		default:
+			out = append(out, withLocation(locs, "encoders", Finding{
+				Severity: SevInfo,
+				Code:     "ENCODER_RATE_OK",
+				Message: fmt.Sprintf(
+					"encoders produce %.0f edges/s within the MCU interrupt budget of %.0f/s (%s)",
+					totalHz,
+					mcu.MaxInterruptRateHz,
+					detail,
+				),
+			}))
+		}
+	}
+	return out
+}
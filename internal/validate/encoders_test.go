@@ -0,0 +1,166 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/badimirzai/robotics-verifier-cli/internal/model"
+)
+
+func encoderSpec() model.RobotSpec {
+	spec := baseSpec()
+	spec.MCU.LogicVoltageV = 3.3
+	spec.MCU.MaxInterruptRateHz = 200000
+	spec.MCU.Pins = []model.MCUPin{
+		{Name: "GPIO4", Caps: []string{"gpio", "interrupt"}},
+		{Name: "GPIO5", Caps: []string{"gpio", "interrupt"}},
+		{Name: "GPIO6", Caps: []string{"gpio", "interrupt"}},
+		{Name: "GPIO7", Caps: []string{"gpio", "interrupt"}},
+		{Name: "GPIO8", Caps: []string{"gpio"}},
+	}
+	spec.Power.Rail = model.Rail{VoltageV: 3.3, MaxCurrentA: 1}
+	spec.Power.Rails = []model.PowerRail{
+		{Name: "periph_5v", Source: "battery", Regulator: "buck", VoltageV: 5, Efficiency: 0.9, MaxCurrentA: 1},
+	}
+	spec.Driver.LogicVoltageMinV = 2.7
+	spec.Motors[0].NoLoadRPM = 150
+	spec.Motors[0].GearRatio = 100
+	n20 := model.MotorEncoder{PPR: 7, SupplyMinV: 3.3, SupplyMaxV: 5, Output: "push_pull"}
+	spec.Encoders = []model.Encoder{
+		{Name: "left", Motor: "M", PinA: "GPIO4", PinB: "GPIO5", MotorEncoder: n20},
+		{Name: "right", Motor: "M", PinA: "GPIO6", PinB: "GPIO7", MotorEncoder: n20},
+	}
+	return spec
+}
+
+func TestRuleEncoders(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*model.RobotSpec)
+		want   []string
+		not    []string
+	}{
+		{
+			name:   "n20_encoders_on_logic_rail",
+			mutate: func(s *model.RobotSpec) {},
+			not: []string{
+				"ENCODER_MOTOR_UNKNOWN", "ENCODER_SUPPLY_RANGE", "ENCODER_LEVEL_MISMATCH", "ENCODER_LEVEL_LOW",
+				"ENCODER_RATE_OVER", "ENCODER_RATE_MARGIN_LOW", "PIN_CAPABILITY", "PIN_CONFLICT",
+			},
+		},
+		{
+			name: "push_pull_from_5v_into_3v3_pins",
+			mutate: func(s *model.RobotSpec) {
+				s.Encoders[0].Rail = "periph_5v"
+			},
+			want: []string{"ENCODER_LEVEL_MISMATCH"},
+			not:  []string{"ENCODER_SUPPLY_RANGE"},
+		},
+		{
+			name: "push_pull_from_5v_into_tolerant_pins",
+			mutate: func(s *model.RobotSpec) {
+				s.Encoders[0].Rail = "periph_5v"
+				s.MCU.FiveVTolerant = boolPtr(true)
+			},
+			not: []string{"ENCODER_LEVEL_MISMATCH"},
+		},
+		{
+			name: "open_collector_on_5v_rail_uses_mcu_pullups",
+			mutate: func(s *model.RobotSpec) {
+				s.Encoders[0].Rail = "periph_5v"
+				s.Encoders[0].Output = "open_collector"
+			},
+			not: []string{"ENCODER_LEVEL_MISMATCH", "ENCODER_LEVEL_LOW"},
+		},
+		{
+			name: "open_collector_pulled_up_to_5v",
+			mutate: func(s *model.RobotSpec) {
+				s.Encoders[0].Output = "open_collector"
+				s.Encoders[0].PullupV = 5
+			},
+			want: []string{"ENCODER_LEVEL_MISMATCH"},
+		},
+		{
+			name: "open_collector_pulled_up_too_low",
+			mutate: func(s *model.RobotSpec) {
+				s.Encoders[0].Output = "open_collector"
+				s.Encoders[0].PullupV = 1.8
+			},
+			want: []string{"ENCODER_LEVEL_LOW"},
+		},
+		{
+			name: "5v_only_encoder_on_3v3",
+			mutate: func(s *model.RobotSpec) {
+				s.Encoders[0].SupplyMinV = 4.5
+			},
+			want: []string{"ENCODER_SUPPLY_RANGE"},
+		},
+		{
+			name: "count_rate_over_interrupt_budget",
+			mutate: func(s *model.RobotSpec) {
+				// 150rpm x 100 / 60 x 7 x 4 = 7000 edges/s per encoder.
+				s.MCU.MaxInterruptRateHz = 10000
+			},
+			want: []string{"ENCODER_RATE_OVER"},
+		},
+		{
+			name: "count_rate_near_interrupt_budget",
+			mutate: func(s *model.RobotSpec) {
+				s.MCU.MaxInterruptRateHz = 16000
+			},
+			want: []string{"ENCODER_RATE_MARGIN_LOW"},
+			not:  []string{"ENCODER_RATE_OVER"},
+		},
+		{
+			name: "x1_decoding_fits_budget",
+			mutate: func(s *model.RobotSpec) {
+				s.MCU.MaxInterruptRateHz = 10000
+				s.Encoders[0].Decoding = 1
+				s.Encoders[1].Decoding = 1
+			},
+			not: []string{"ENCODER_RATE_OVER", "ENCODER_RATE_MARGIN_LOW"},
+		},
+		{
+			name: "pin_without_interrupt",
+			mutate: func(s *model.RobotSpec) {
+				s.Encoders[1].PinB = "GPIO8"
+			},
+			want: []string{"PIN_CAPABILITY"},
+		},
+		{
+			name: "encoders_share_a_pin",
+			mutate: func(s *model.RobotSpec) {
+				s.Encoders[1].PinA = "GPIO4"
+			},
+			want: []string{"PIN_CONFLICT"},
+		},
+		{
+			name: "unknown_motor",
+			mutate: func(s *model.RobotSpec) {
+				s.Encoders[0].Motor = "arm"
+			},
+			want: []string{"ENCODER_MOTOR_UNKNOWN"},
+		},
+		{
+			name: "bad_output_and_decoding",
+			mutate: func(s *model.RobotSpec) {
+				s.Encoders[0].Output = "ttl"
+				s.Encoders[0].Decoding = 3
+			},
+			want: []string{"ENCODER_OUTPUT_INVALID", "ENCODER_DECODING_INVALID"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := encoderSpec()
+			tt.mutate(&spec)
+			codes := reportCodes(RunAll(spec, nil))
+			for _, c := range tt.want {
+				requireHasCode(t, codes, c)
+			}
+			for _, c := range tt.not {
+				requireNoCode(t, codes, c)
+			}
+		})
+	}
+}
@@ -0,0 +1,336 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/badimirzai/robotics-verifier-cli/internal/model"
+)
+
+// awgRatingA is the chassis wiring ampacity of copper wire by gauge, the usual
+// figure for short runs in free air.
+var awgRatingA = map[int]float64{
+	10: 55,
+	12: 41,
+	14: 32,
+	16: 22,
+	18: 16,
+	20: 11,
+	22: 7,
+	24: 3.5,
+	26: 2.2,
+	28: 1.4,
+}
+
+// awgOhmPerKm is the resistance of annealed copper wire by gauge at 20°C.
+var awgOhmPerKm = map[int]float64{
+	10: 3.277,
+	12: 5.211,
+	14: 8.286,
+	16: 13.17,
+	18: 20.95,
+	20: 33.31,
+	22: 52.96,
+	24: 84.22,
+	26: 133.9,
+	28: 212.9,
+}
+
+// fuseMarginFraction is how much of the fuse rating the nominal load may use before
+// ageing and temperature derating make nuisance trips likely.
+const fuseMarginFraction = 0.8
+
+// harnessLoad is the current through a harness segment.
+type harnessLoad struct {
+	Label    string
+	NominalA float64
+	StallA   float64 // every motor behind the segment stalled
+}
+
+// segmentLoad returns the current a harness segment carries. Stall is the non-motor
+// load plus every motor at its peak, brushless drives at max current on the battery
+// lead, and never less than nominal.
+func segmentLoad(spec model.RobotSpec, carries string) (harnessLoad, bool) {
+	var l harnessLoad
+	loads := railLoads(spec)
+	if carries == "" || carries == batterySource {
+		l = harnessLoad{
+			Label:    "battery",
+			NominalA: loads[batterySource].ContinuousA,
+			StallA:   batteryPeakA(spec),
+		}
+	} else if _, ok := findRail(spec, carries); ok {
+		l = harnessLoad{
+			Label:    "rail " + carries,
+			NominalA: loads[carries].ContinuousA,
+			StallA:   railLoadsFrom(spec, stallLoads(spec))[carries].ContinuousA,
+		}
+	} else {
+		idx := -1
+		entries := driverEntries(spec)
+		for i, e := range entries {
+			if carries == e.Path || (e.Driver.Name != "" && carries == e.Driver.Name) {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			return harnessLoad{}, false
+		}
+		l.Label = entries[idx].Label() + " motor supply"
+		for _, iw := range wireInstances(spec, entries) {
+			if iw.Driver != idx {
+				continue
+			}
+			l.NominalA += iw.Motor.NominalCurrentA
+			l.StallA += instanceStallA(spec, entries, iw)
+		}
+	}
+	if l.StallA < l.NominalA {
+		l.StallA = l.NominalA
+	}
+	return l, true
+}
+
+// wireRatingA is the declared wire rating, else the AWG table value.
+func wireRatingA(seg model.HarnessSegment) (float64, bool) {
+	if seg.WireRatingA > 0 {
+		return seg.WireRatingA, true
+	}
+	a, ok := awgRatingA[seg.AWG]
+	return a, ok
+}
+
+// segmentResistanceOhm is the round trip resistance of a harness segment, supply and
+// return conductor, or 0 when its length or gauge is unknown.
+func segmentResistanceOhm(seg model.HarnessSegment) float64 {
+	ohmPerKm, ok := awgOhmPerKm[seg.AWG]
+	if !ok || seg.LengthM <= 0 {
+		return 0
+	}
+	return 2 * seg.LengthM * ohmPerKm / 1000
+}
+
+func segmentCarries(seg model.HarnessSegment) string {
+	if seg.Carries == "" {
+		return batterySource
+	}
+	return seg.Carries
+}
+
+// harnessDropV sums the I·R drop at nominal and stall current of every harness segment
+// carrying one of names (a supply name, driver path or driver name).
+func harnessDropV(spec model.RobotSpec, names ...string) (nominalV, stallV float64) {
+	for _, seg := range spec.Harness {
+		r := segmentResistanceOhm(seg)
+		if r == 0 {
+			continue
+		}
+		for _, name := range names {
+			if name == "" || name != segmentCarries(seg) {
+				continue
+			}
+			if load, ok := segmentLoad(spec, seg.Carries); ok {
+				nominalV += r * load.NominalA
+				stallV += r * load.StallA
+			}
+			break
+		}
+	}
+	return nominalV, stallV
+}
+
+func connectorPeakA(c model.Connector) float64 {
+	if c.PeakRatingA > 0 {
+		return c.PeakRatingA
+	}
+	return c.RatingA
+}
+
+func ruleHarness(spec model.RobotSpec, locs map[string]Location) []Finding {
+	var out []Finding
+	for i, seg := range spec.Harness {
+		base := fmt.Sprintf("harness[%d]", i)
+		name := seg.Name
+		if name == "" {
+			name = base
+		}
+		load, ok := segmentLoad(spec, seg.Carries)
+		if !ok {
+			out = append(out, withLocation(locs, base+".carries", Finding{
+				Severity: SevError,
+				Code:     "HARNESS_CARRIES_UNKNOWN",
+				Message:  fmt.Sprintf("harness %s carries %q, which is not battery, a power.rails name or a motor driver", name, seg.Carries),
+			}))
+			continue
+		}
+		before := len(out)
+
+		wireA, haveWire := wireRatingA(seg)
+		if !haveWire && seg.AWG != 0 {
+			out = append(out, withLocation(locs, base+".awg", Finding{
+				Severity: SevWarn,
+				Code:     "WIRE_AWG_UNKNOWN",
+				Message:  fmt.Sprintf("harness %s AWG %d is not in the 10 to 28 table; set wire_rating_a", name, seg.AWG),
+			}))
+		}
+		if haveWire {
+			fused := seg.FuseA > 0 && seg.FuseA <= wireA
+			switch {
+			case load.NominalA > wireA:
+				out = append(out, withLocation(locs, base, Finding{
+					Severity: SevError,
+					Code:     "WIRE_OVERLOAD",
+					Message: fmt.Sprintf(
+						"harness %s carries %.2fA nominal (%s), above its %.2fA wire rating. Use a heavier gauge.",
+						name, load.NominalA, load.Label, wireA,
+					),
+				}))
+			case load.StallA > wireA && !fused:
+				out = append(out, withLocation(locs, base, Finding{
+					Severity: SevWarn,
+					Code:     "WIRE_STALL_OVER",
+					Message: fmt.Sprintf(
+						"harness %s carries %.2fA with every motor stalled (%s), above its %.2fA wire rating, and no fuse protects it",
+						name, load.StallA, load.Label, wireA,
+					),
+				}))
+			}
+		}
+
+		if seg.FuseA > 0 {
+			switch {
+			case load.NominalA >= seg.FuseA:
+				out = append(out, withLocation(locs, base+".fuse_a", Finding{
+					Severity: SevError,
+					Code:     "FUSE_BELOW_LOAD",
+					Message: fmt.Sprintf(
+						"harness %s fuse %.2fA is at or below the %.2fA nominal load (%s) and will blow in normal running",
+						name, seg.FuseA, load.NominalA, load.Label,
+					),
+				}))
+			case load.NominalA > fuseMarginFraction*seg.FuseA:
+				out = append(out, withLocation(locs, base+".fuse_a", Finding{
+					Severity: SevWarn,
+					Code:     "FUSE_MARGIN_LOW",
+					Message: fmt.Sprintf(
+						"harness %s nominal load %.2fA (%s) is above %.0f%% of its %.2fA fuse; expect nuisance trips",
+						name, load.NominalA, load.Label, fuseMarginFraction*100, seg.FuseA,
+					),
+				}))
+			}
+			if haveWire && seg.FuseA > wireA {
+				out = append(out, withLocation(locs, base+".fuse_a", Finding{
+					Severity: SevError,
+					Code:     "FUSE_ABOVE_WIRE",
+					Message: fmt.Sprintf(
+						"harness %s fuse %.2fA is above its %.2fA wire rating; the wire fails before the fuse trips",
+						name, seg.FuseA, wireA,
+					),
+				}))
+			}
+		}
+
+		for j, c := range seg.Connectors {
+			path := fmt.Sprintf("%s.connectors[%d]", base, j)
+			label := c.Name
+			if label == "" {
+				label = path
+			}
+			if c.RatingA <= 0 {
+				continue
+			}
+			if seg.FuseA > c.RatingA {
+				out = append(out, withLocation(locs, path, Finding{
+					Severity: SevError,
+					Code:     "FUSE_ABOVE_CONNECTOR",
+					Message: fmt.Sprintf(
+						"harness %s fuse %.2fA is above the %.2fA rating of %s",
+						name, seg.FuseA, c.RatingA, label,
+					),
+				}))
+			}
+			switch {
+			case load.NominalA > c.RatingA:
+				out = append(out, withLocation(locs, path, Finding{
+					Severity: SevError,
+					Code:     "CONNECTOR_OVERLOAD",
+					Message: fmt.Sprintf(
+						"%s on harness %s carries %.2fA nominal (%s), above its %.2fA rating",
+						label, name, load.NominalA, load.Label, c.RatingA,
+					),
+				}))
+			case load.StallA > connectorPeakA(c):
+				out = append(out, withLocation(locs, path, Finding{
+					Severity: SevError,
+					Code:     "CONNECTOR_OVERLOAD",
+					Message: fmt.Sprintf(
+						"%s on harness %s carries %.2fA with every motor stalled (%s), above its %.2fA peak rating. Use a power connector.",
+						label, name, load.StallA, load.Label, connectorPeakA(c),
+					),
+				}))
+			}
+		}
+
+		if seg.FuseA <= 0 && (seg.Carries == "" || seg.Carries == batterySource) {
+			out = append(out, withLocation(locs, base, Finding{
+				Severity: SevWarn,
+				Code:     "HARNESS_UNFUSED",
+				Message:  fmt.Sprintf("harness %s runs straight from the battery without a fuse; a short will burn the wiring", name),
+			}))
+		}
+
+		if r := segmentResistanceOhm(seg); r > 0 {
+			out = append(out, withLocation(locs, base+".length_m", Finding{
+				Severity: SevInfo,
+				Code:     "HARNESS_DROP",
+				Message: fmt.Sprintf(
+					"harness %s drops %.2fV at %.2fA nominal and %.2fV at %.2fA stall (2 x %.2fm of %d AWG, %.3fΩ)",
+					name, r*load.NominalA, load.NominalA, r*load.StallA, load.StallA, seg.LengthM, seg.AWG, r,
+				),
+				Meta: map[string]any{
+					"resistance_ohm": roundMilli(r),
+					"drop_nominal_v": roundMilli(r * load.NominalA),
+					"drop_stall_v":   roundMilli(r * load.StallA),
+				},
+			}))
+			before++
+		}
+
+		if len(out) == before {
+			var parts []string
+			if haveWire {
+				parts = append(parts, fmt.Sprintf("wire %.2fA", wireA))
+			}
+			if seg.FuseA > 0 {
+				parts = append(parts, fmt.Sprintf("fuse %.2fA", seg.FuseA))
+			}
+			for _, c := range seg.Connectors {
+				if c.RatingA > 0 {
+					parts = append(parts, fmt.Sprintf("%s %.2fA", c.Name, c.RatingA))
+				}
+			}
+			if len(parts) == 0 {
+				continue
+			}
+			out = append(out, withLocation(locs, base, Finding{
+				Severity: SevInfo,
+				Code:     "HARNESS_OK",
+				Message: fmt.Sprintf(
+					"harness %s carries %.2fA nominal, %.2fA stall (%s) within %s",
+					name, load.NominalA, load.StallA, load.Label, strings.Join(parts, ", "),
+				),
+			}))
+		}
+	}
+
+	// Supply windows already check the voltage after the nominal drop; a stall only
+	// lasts a moment, so falling out of the window then is a warning.
+	for _, w := range supplyWindows(spec) {
+		if w.StallDropV <= 0 || w.outside(w.loadedV()) || !w.outside(w.stallV()) {
+			continue
+		}
+		out = append(out, w.finding(locs, SevWarn, w.stallV(), " with every motor stalled"))
+	}
+	return out
+}
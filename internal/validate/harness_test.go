@@ -0,0 +1,241 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/badimirzai/robotics-verifier-cli/internal/model"
+)
+
+// harnessSpec feeds the base robot through a fused 18 AWG battery lead with an XT30.
+func harnessSpec() model.RobotSpec {
+	spec := baseSpec()
+	spec.Harness = []model.HarnessSegment{{
+		Name:       "battery lead",
+		AWG:        18,
+		FuseA:      10,
+		Connectors: []model.Connector{{Name: "XT30", RatingA: 15, PeakRatingA: 30}},
+	}}
+	return spec
+}
+
+// longDriverRun is 10m of 22 AWG to the motor driver, about 1.06Ω round trip.
+func longDriverRun() model.HarnessSegment {
+	return model.HarnessSegment{Name: "arm run", Carries: "motor_driver", AWG: 22, LengthM: 10}
+}
+
+func TestRuleHarness(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*model.RobotSpec)
+		want   []string
+		not    []string
+	}{
+		{
+			name:   "fused_battery_lead",
+			mutate: func(s *model.RobotSpec) {},
+			want:   []string{"HARNESS_OK"},
+			not:    []string{"WIRE_OVERLOAD", "FUSE_BELOW_LOAD", "FUSE_ABOVE_WIRE", "CONNECTOR_OVERLOAD", "HARNESS_UNFUSED"},
+		},
+		{
+			name: "fuse_below_nominal_load",
+			mutate: func(s *model.RobotSpec) {
+				s.Harness[0].FuseA = 2
+			},
+			want: []string{"FUSE_BELOW_LOAD"},
+			not:  []string{"HARNESS_OK"},
+		},
+		{
+			name: "fuse_close_to_nominal_load",
+			mutate: func(s *model.RobotSpec) {
+				s.Harness[0].FuseA = 2.2
+			},
+			want: []string{"FUSE_MARGIN_LOW"},
+			not:  []string{"FUSE_BELOW_LOAD"},
+		},
+		{
+			name: "fuse_above_wire",
+			mutate: func(s *model.RobotSpec) {
+				s.Harness[0].AWG = 24
+			},
+			want: []string{"FUSE_ABOVE_WIRE"},
+		},
+		{
+			name: "fuse_above_connector",
+			mutate: func(s *model.RobotSpec) {
+				s.Harness[0].Connectors[0] = model.Connector{Name: "JST-XH", RatingA: 3}
+			},
+			want: []string{"FUSE_ABOVE_CONNECTOR", "CONNECTOR_OVERLOAD"},
+		},
+		{
+			name: "wire_overload",
+			mutate: func(s *model.RobotSpec) {
+				s.Harness[0].AWG = 28
+				s.Harness[0].FuseA = 0
+			},
+			want: []string{"WIRE_OVERLOAD", "HARNESS_UNFUSED"},
+		},
+		{
+			name: "stall_over_unfused_wire",
+			mutate: func(s *model.RobotSpec) {
+				s.Harness[0].AWG = 24
+				s.Harness[0].FuseA = 0
+			},
+			want: []string{"WIRE_STALL_OVER", "HARNESS_UNFUSED"},
+			not:  []string{"WIRE_OVERLOAD"},
+		},
+		{
+			name: "dupont_jumper_on_motor_stall",
+			mutate: func(s *model.RobotSpec) {
+				s.Motors[0].Count = 1
+				s.Motors[0].NominalCurrentA = 0.5
+				s.Harness = []model.HarnessSegment{{
+					Name:       "driver jumper",
+					Carries:    "motor_driver",
+					AWG:        26,
+					Connectors: []model.Connector{{Name: "Dupont", RatingA: 1}},
+				}}
+			},
+			want: []string{"CONNECTOR_OVERLOAD"},
+			not:  []string{"HARNESS_UNFUSED", "HARNESS_CARRIES_UNKNOWN"},
+		},
+		{
+			name: "rail_segment",
+			mutate: func(s *model.RobotSpec) {
+				s.Power.Rails = []model.PowerRail{{Name: "5v", Regulator: "buck", VoltageV: 5, Efficiency: 0.9, MaxCurrentA: 3}}
+				s.MCU.Rail = "5v"
+				s.MCU.SupplyCurrent = model.SupplyCurrent{TypicalA: 0.2}
+				s.Harness = []model.HarnessSegment{{Name: "5v lead", Carries: "5v", AWG: 22, FuseA: 1}}
+			},
+			want: []string{"HARNESS_OK"},
+			not:  []string{"HARNESS_CARRIES_UNKNOWN", "HARNESS_UNFUSED"},
+		},
+		{
+			name: "unknown_carries",
+			mutate: func(s *model.RobotSpec) {
+				s.Harness[0].Carries = "arm"
+			},
+			want: []string{"HARNESS_CARRIES_UNKNOWN"},
+			not:  []string{"HARNESS_OK"},
+		},
+		{
+			name: "awg_outside_table",
+			mutate: func(s *model.RobotSpec) {
+				s.Harness[0].AWG = 8
+			},
+			want: []string{"WIRE_AWG_UNKNOWN"},
+		},
+		{
+			name: "wire_rating_overrides_awg",
+			mutate: func(s *model.RobotSpec) {
+				s.Harness[0].AWG = 8
+				s.Harness[0].WireRatingA = 60
+			},
+			want: []string{"HARNESS_OK"},
+			not:  []string{"WIRE_AWG_UNKNOWN"},
+		},
+		{
+			name: "drop_reported",
+			mutate: func(s *model.RobotSpec) {
+				s.Harness[0].LengthM = 0.5
+			},
+			want: []string{"HARNESS_DROP", "HARNESS_OK"},
+			not:  []string{"DRV_SUPPLY_RANGE"},
+		},
+		{
+			name: "long_run_below_driver_minimum",
+			mutate: func(s *model.RobotSpec) {
+				s.Driver.MotorSupplyMinV = 10.5
+				s.Harness = append(s.Harness, longDriverRun())
+			},
+			want: []string{"DRV_SUPPLY_RANGE", "HARNESS_DROP"},
+		},
+		{
+			name: "long_run_only_drops_out_at_stall",
+			mutate: func(s *model.RobotSpec) {
+				s.Harness = append(s.Harness, longDriverRun())
+			},
+			want: []string{"DRV_SUPPLY_RANGE", "HARNESS_DROP"},
+		},
+		{
+			name: "short_heavy_run",
+			mutate: func(s *model.RobotSpec) {
+				run := longDriverRun()
+				run.AWG, run.LengthM = 14, 0.3
+				s.Harness = append(s.Harness, run)
+			},
+			want: []string{"HARNESS_DROP"},
+			not:  []string{"DRV_SUPPLY_RANGE"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := harnessSpec()
+			tt.mutate(&spec)
+			codes := reportCodes(RunAll(spec, nil))
+			for _, c := range tt.want {
+				requireHasCode(t, codes, c)
+			}
+			for _, c := range tt.not {
+				requireNoCode(t, codes, c)
+			}
+		})
+	}
+}
+
+func TestHarnessDropFeedsSupplyRange(t *testing.T) {
+	spec := harnessSpec()
+	spec.Harness = append(spec.Harness, longDriverRun())
+
+	var f *Finding
+	for _, got := range RunAll(spec, nil).Findings {
+		if got.Code == "DRV_SUPPLY_RANGE" {
+			f = &got
+			break
+		}
+	}
+	if f == nil {
+		t.Fatalf("expected DRV_SUPPLY_RANGE from the stall drop")
+	}
+	if f.Severity != SevWarn {
+		t.Errorf("expected a stall only drop to warn, got %s", f.Severity)
+	}
+	drop, _ := f.Meta["harness_drop_v"].(float64)
+	load, _ := f.Meta["load_v"].(float64)
+	if drop <= 6 || load >= 6 || f.Meta["supply_v"] != 12.0 {
+		t.Errorf("unexpected drop meta: %+v", f.Meta)
+	}
+
+	spec.Driver.MotorSupplyMinV = 10.5
+	for _, got := range RunAll(spec, nil).Findings {
+		if got.Code == "DRV_SUPPLY_RANGE" && got.Severity != SevError {
+			t.Errorf("expected the nominal drop to be an error, got %s: %s", got.Severity, got.Message)
+		}
+	}
+}
+
+func TestSegmentLoadStallCountsEveryMotorType(t *testing.T) {
+	specs := map[string]model.RobotSpec{
+		"servo":   servoSpec(),
+		"stepper": stepperSpec(),
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			load, ok := segmentLoad(spec, "")
+			if !ok {
+				t.Fatalf("expected a battery lead load")
+			}
+			if load.StallA < load.NominalA {
+				t.Errorf("expected stall %.3fA to be at least nominal %.3fA", load.StallA, load.NominalA)
+			}
+		})
+	}
+
+	spec := servoSpec()
+	with, _ := segmentLoad(spec, "")
+	spec.Servos = nil
+	without, _ := segmentLoad(spec, "")
+	if with.StallA <= without.StallA {
+		t.Errorf("expected servo stall to raise the battery lead stall, got %.3fA with and %.3fA without", with.StallA, without.StallA)
+	}
+}
@@ -0,0 +1,608 @@
+package validate
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/badimirzai/robotics-verifier-cli/internal/model"
+)
+
+// i2cMode holds the I2C specification limits for one speed mode (UM10204 table 10).
+type i2cMode struct {
+	Name     string
+	MaxKHz   float64
+	RiseNs   float64 // max SDA/SCL rise time, 30% to 70%
+	MaxCapPF float64 // max capacitive load per bus line
+	SinkMA   float64 // low level output current at VOL 0.4V
+}
+
+var i2cModes = []i2cMode{
+	{Name: "standard mode", MaxKHz: 100, RiseNs: 1000, MaxCapPF: 400, SinkMA: 3},
+	{Name: "fast mode", MaxKHz: 400, RiseNs: 300, MaxCapPF: 400, SinkMA: 3},
+	{Name: "fast mode plus", MaxKHz: 1000, RiseNs: 120, MaxCapPF: 550, SinkMA: 20},
+}
+
+// i2cVOL is the low level a device must reach while sinking the pull-up current.
+const i2cVOL = 0.4
+
+// tracePFPerCm estimates bus wiring capacitance from trace_length_cm. PCB traces over a
+// ground plane and short jumper wires are both close to 1pF/cm.
+const tracePFPerCm = 1.0
+
+// i2cModeFor returns the slowest mode that covers speedKHz.
+func i2cModeFor(speedKHz float64) (i2cMode, bool) {
+	for _, m := range i2cModes {
+		if speedKHz <= m.MaxKHz {
+			return m, true
+		}
+	}
+	return i2cMode{}, false
+}
+
+// i2cNode is a device on an I2C bus: a sensor entry, a mux or a PWM controller
+// naming the bus.
+type i2cNode struct {
+	Name             string
+	Address          uint16
+	PinCapacitancePF float64
+	MaxSpeedKHz      float64
+	Addresses        []model.I2CAddressOption
+	Path             string
+}
+
+func deviceNode(d model.I2CDevice, path string) i2cNode {
+	return i2cNode{
+		Name:             d.Name,
+		Address:          uint16(d.AddressHex),
+		PinCapacitancePF: d.PinCapacitancePF,
+		MaxSpeedKHz:      d.MaxSpeedKHz,
+		Addresses:        d.Addresses,
+		Path:             path,
+	}
+}
+
+// i2cNodes lists the devices directly on a bus, muxes included but not what sits
+// behind them.
+func i2cNodes(spec model.RobotSpec, busIndex int) []i2cNode {
+	bus := spec.I2CBuses[busIndex]
+	var out []i2cNode
+	for i, d := range bus.Devices {
+		out = append(out, deviceNode(d, fmt.Sprintf("i2c_buses[%d].devices[%d]", busIndex, i)))
+	}
+	for i, c := range spec.PWM {
+		if c.Bus != bus.Name {
+			continue
+		}
+		out = append(out, i2cNode{
+			Name:             c.Name,
+			Address:          uint16(c.AddressHex),
+			PinCapacitancePF: c.PinCapacitancePF,
+			MaxSpeedKHz:      c.MaxSpeedKHz,
+			Addresses:        c.Addresses,
+			Path:             fmt.Sprintf("pwm_controllers[%d]", i),
+		})
+	}
+	return out
+}
+
+// i2cSegment is one stretch of an I2C bus: the bus itself or the far side of a mux
+// channel. Upstream holds the nodes a segment also sees while its channel is selected.
+type i2cSegment struct {
+	Name     string // "i2c0" or "i2c0/tof_mux ch2"
+	Path     string
+	Nodes    []i2cNode
+	Upstream []i2cNode
+}
+
+// i2cSegments returns the bus and every segment behind its muxes, parents first.
+func i2cSegments(spec model.RobotSpec, busIndex int) []i2cSegment {
+	bus := spec.I2CBuses[busIndex]
+	root := i2cSegment{
+		Name:  bus.Name,
+		Path:  fmt.Sprintf("i2c_buses[%d]", busIndex),
+		Nodes: i2cNodes(spec, busIndex),
+	}
+	out := []i2cSegment{root}
+	var walk func(parent i2cSegment, devices []model.I2CDevice)
+	walk = func(parent i2cSegment, devices []model.I2CDevice) {
+		upstream := append(append([]i2cNode{}, parent.Upstream...), parent.Nodes...)
+		for i, mux := range devices {
+			// A channel listed twice is still one segment.
+			byChannel := make(map[int]int)
+			for j, seg := range mux.Segments {
+				path := fmt.Sprintf("%s.devices[%d].segments[%d]", parent.Path, i, j)
+				idx, seen := byChannel[seg.Channel]
+				if !seen {
+					idx = len(out)
+					byChannel[seg.Channel] = idx
+					out = append(out, i2cSegment{
+						Name:     fmt.Sprintf("%s/%s ch%d", parent.Name, mux.Name, seg.Channel),
+						Path:     path,
+						Upstream: upstream,
+					})
+				}
+				for k, d := range seg.Devices {
+					out[idx].Nodes = append(out[idx].Nodes, deviceNode(d, fmt.Sprintf("%s.devices[%d]", path, k)))
+				}
+				walk(i2cSegment{Name: out[idx].Name, Path: path, Nodes: out[idx].Nodes, Upstream: upstream}, seg.Devices)
+			}
+		}
+	}
+	walk(root, bus.Devices)
+	return out
+}
+
+// i2cBusDevice is a device anywhere on a bus, behind muxes included.
+type i2cBusDevice struct {
+	Device model.I2CDevice
+	Path   string
+}
+
+func i2cBusDevices(bus model.I2CBus, busIndex int) []i2cBusDevice {
+	var out []i2cBusDevice
+	var walk func(devices []model.I2CDevice, path string)
+	walk = func(devices []model.I2CDevice, path string) {
+		for i, d := range devices {
+			p := fmt.Sprintf("%s.devices[%d]", path, i)
+			out = append(out, i2cBusDevice{Device: d, Path: p})
+			for j, seg := range d.Segments {
+				walk(seg.Devices, fmt.Sprintf("%s.segments[%d]", p, j))
+			}
+		}
+	}
+	walk(bus.Devices, fmt.Sprintf("i2c_buses[%d]", busIndex))
+	return out
+}
+
+// i2cMux is suggested when strapping cannot separate devices sharing an address.
+const i2cMux = "TCA9548A"
+
+// i2cMove is a suggested address change for one device.
+type i2cMove struct {
+	Node    i2cNode
+	Address uint16
+	Strap   string
+}
+
+// i2cCandidates lists the addresses a node may use, its current address first.
+func i2cCandidates(n i2cNode) []uint16 {
+	out := []uint16{n.Address}
+	for _, o := range n.Addresses {
+		if a := uint16(o.AddressHex); a != n.Address {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+func (n i2cNode) strapFor(addr uint16) string {
+	for _, o := range n.Addresses {
+		if uint16(o.AddressHex) == addr {
+			return o.Strap
+		}
+	}
+	return ""
+}
+
+// matchI2CAddresses gives every node a distinct candidate address outside taken
+// (bipartite matching by augmenting paths). Earlier candidates are preferred.
+func matchI2CAddresses(nodes []i2cNode, taken map[uint16]bool) (map[int]uint16, bool) {
+	owner := make(map[uint16]int)
+	var try func(i int, seen map[uint16]bool) bool
+	try = func(i int, seen map[uint16]bool) bool {
+		for _, a := range i2cCandidates(nodes[i]) {
+			if taken[a] || seen[a] {
+				continue
+			}
+			seen[a] = true
+			if j, ok := owner[a]; !ok || try(j, seen) {
+				owner[a] = i
+				return true
+			}
+		}
+		return false
+	}
+	for i := range nodes {
+		if !try(i, make(map[uint16]bool)) {
+			return nil, false
+		}
+	}
+	out := make(map[int]uint16, len(owner))
+	for a, i := range owner {
+		out[i] = a
+	}
+	return out, true
+}
+
+// suggestI2CAddresses finds a conflict free assignment from each node's strapping
+// options. It first keeps every device that is already unique plus the first of each
+// clashing group in place and only moves the rest; when that fails any device may
+// move. ok is false when no assignment exists.
+func suggestI2CAddresses(nodes []i2cNode) ([]i2cMove, bool) {
+	seen := make(map[uint16]bool)
+	taken := make(map[uint16]bool)
+	var movers []i2cNode
+	for _, n := range nodes {
+		if seen[n.Address] {
+			movers = append(movers, n)
+			continue
+		}
+		seen[n.Address] = true
+		taken[n.Address] = true
+	}
+	if len(movers) == 0 {
+		return nil, true
+	}
+	candidates := movers
+	assigned, ok := matchI2CAddresses(movers, taken)
+	if !ok {
+		candidates = nodes
+		assigned, ok = matchI2CAddresses(nodes, nil)
+		if !ok {
+			return nil, false
+		}
+	}
+	var out []i2cMove
+	for i, n := range candidates {
+		if a := assigned[i]; a != n.Address {
+			out = append(out, i2cMove{Node: n, Address: a, Strap: n.strapFor(a)})
+		}
+	}
+	return out, true
+}
+
+// ruleI2CAddressConflict checks every bus segment. A segment behind a mux also sees
+// the devices upstream of it, so those count as fixed; siblings on other channels
+// never meet. Only clashes involving a device on the segment itself are reported.
+func ruleI2CAddressConflict(spec model.RobotSpec, locs map[string]Location) []Finding {
+	var out []Finding
+	for busIndex := range spec.I2CBuses {
+		for _, seg := range i2cSegments(spec, busIndex) {
+			out = append(out, segmentAddressConflicts(seg, locs)...)
+		}
+	}
+	return out
+}
+
+func segmentAddressConflicts(seg i2cSegment, locs map[string]Location) []Finding {
+	var out []Finding
+	var nodes []i2cNode
+	addresses := make(map[uint16][]string)
+	local := make(map[uint16]bool)
+	for _, n := range seg.Upstream {
+		if n.Address == 0 {
+			continue
+		}
+		n.Addresses = nil
+		nodes = append(nodes, n)
+		addresses[n.Address] = append(addresses[n.Address], n.Name)
+	}
+	for _, n := range seg.Nodes {
+		if n.Address == 0 {
+			continue
+		}
+		nodes = append(nodes, n)
+		addresses[n.Address] = append(addresses[n.Address], n.Name)
+		local[n.Address] = true
+	}
+	var clashing []uint16
+	for addr, names := range addresses {
+		if len(names) > 1 && local[addr] {
+			clashing = append(clashing, addr)
+		}
+	}
+	if len(clashing) == 0 {
+		return nil
+	}
+	sort.Slice(clashing, func(i, j int) bool { return clashing[i] < clashing[j] })
+
+	moves, ok := suggestI2CAddresses(nodes)
+	var hint string
+	fixIt := map[string]any{}
+	if ok {
+		steps := make([]string, 0, len(moves))
+		changes := make([]map[string]any, 0, len(moves))
+		for _, m := range moves {
+			step := fmt.Sprintf("%s to 0x%X", m.Node.Name, m.Address)
+			if m.Strap != "" {
+				step += " (" + m.Strap + ")"
+			}
+			steps = append(steps, step)
+			changes = append(changes, map[string]any{
+				"device":  m.Node.Name,
+				"path":    m.Node.Path + ".address_hex",
+				"address": fmt.Sprintf("0x%X", m.Address),
+				"strap":   m.Strap,
+			})
+		}
+		hint = "Move " + strings.Join(steps, ", ") + "."
+		fixIt["action"] = "reassign"
+		fixIt["changes"] = changes
+	} else if len(seg.Upstream) > 0 {
+		hint = "No strapping option separates them; put them on separate mux channels."
+		fixIt["action"] = "split_channels"
+	} else {
+		hint = fmt.Sprintf("No strapping option separates them; add an I2C mux (%s) and put them on separate channels.", i2cMux)
+		fixIt["action"] = "add_mux"
+		fixIt["mux"] = i2cMux
+	}
+
+	for _, addr := range clashing {
+		names := addresses[addr]
+		out = append(out, withLocation(locs, seg.Path+".devices", Finding{
+			Severity: SevError,
+			Code:     "I2C_ADDR_CONFLICT",
+			Message: fmt.Sprintf(
+				"Devices %s share I2C address 0x%X on bus %s. %s",
+				strings.Join(names, ", "),
+				addr,
+				seg.Name,
+				hint,
+			),
+			Meta: map[string]any{
+				"bus":     seg.Name,
+				"address": fmt.Sprintf("0x%X", addr),
+				"devices": names,
+				"fix_it":  fixIt,
+			},
+		}))
+	}
+	return out
+}
+
+// i2cReserved reports whether a 7 bit address is reserved by the I2C specification:
+// 0x00..0x07 for general call, CBUS, HS mode masters and future use, 0x78..0x7F for
+// 10 bit addressing and device ID.
+func i2cReserved(addr uint16) bool {
+	return addr <= 0x07 || addr >= 0x78
+}
+
+// ruleI2CTopology flags reserved addresses and mux segments on channels the mux
+// does not have.
+func ruleI2CTopology(spec model.RobotSpec, locs map[string]Location) []Finding {
+	var out []Finding
+	for busIndex, bus := range spec.I2CBuses {
+		for _, seg := range i2cSegments(spec, busIndex) {
+			for _, n := range seg.Nodes {
+				if n.Address == 0 || !i2cReserved(n.Address) {
+					continue
+				}
+				reason := "reserved by the I2C specification"
+				if n.Address > 0x7F {
+					reason = "not a 7 bit address"
+				}
+				out = append(out, withLocation(locs, n.Path+".address_hex", Finding{
+					Severity: SevError,
+					Code:     "I2C_ADDR_RESERVED",
+					Message: fmt.Sprintf(
+						"%s address 0x%X on bus %s is %s (use 0x08..0x77)",
+						n.Name,
+						n.Address,
+						seg.Name,
+						reason,
+					),
+				}))
+			}
+		}
+		for _, d := range i2cBusDevices(bus, busIndex) {
+			used := make(map[int]bool)
+			for j, seg := range d.Device.Segments {
+				path := fmt.Sprintf("%s.segments[%d].channel", d.Path, j)
+				var problem string
+				switch {
+				case seg.Channel < 0:
+					problem = fmt.Sprintf("on %s is negative", d.Device.Name)
+				case d.Device.MuxChannels > 0 && seg.Channel >= d.Device.MuxChannels:
+					problem = fmt.Sprintf("does not exist on %s (channels 0..%d)", d.Device.Name, d.Device.MuxChannels-1)
+				case used[seg.Channel]:
+					problem = fmt.Sprintf("is listed twice on %s; merge the segments", d.Device.Name)
+				}
+				used[seg.Channel] = true
+				if problem == "" {
+					continue
+				}
+				out = append(out, withLocation(locs, path, Finding{
+					Severity: SevError,
+					Code:     "I2C_MUX_CHANNEL",
+					Message:  fmt.Sprintf("mux channel %d %s", seg.Channel, problem),
+				}))
+			}
+		}
+	}
+	return out
+}
+
+// i2cBusCapacitancePF is the wiring capacitance plus every device pin on the bus.
+func i2cBusCapacitancePF(spec model.RobotSpec, busIndex int) float64 {
+	bus := spec.I2CBuses[busIndex]
+	c := bus.CapacitancePF
+	if c <= 0 {
+		c = bus.TraceLengthCm * tracePFPerCm
+	}
+	for _, n := range i2cNodes(spec, busIndex) {
+		c += n.PinCapacitancePF
+	}
+	return c
+}
+
+// i2cPullupV returns the voltage the pull-ups are tied to: declared, else the bus rail.
+func i2cPullupV(spec model.RobotSpec, bus model.I2CBus) (float64, string) {
+	if bus.PullupVoltageV > 0 {
+		return bus.PullupVoltageV, "pullup_voltage_v"
+	}
+	rail, ok := logicSupply(spec, bus.Rail)
+	if !ok {
+		return 0, ""
+	}
+	return rail.VoltageV, rail.Label()
+}
+
+// i2cRiseNs is the 30% to 70% rise time of an RC loaded bus line.
+func i2cRiseNs(pullupOhm, capPF float64) float64 {
+	return 0.8473 * pullupOhm * capPF / 1000
+}
+
+func ruleI2CElectrical(spec model.RobotSpec, locs map[string]Location) []Finding {
+	var out []Finding
+	mcuLogicV := spec.MCU.LogicVoltageV
+	for busIndex, bus := range spec.I2CBuses {
+		base := fmt.Sprintf("i2c_buses[%d]", busIndex)
+		speed := bus.SpeedKHz
+		if speed < 0 {
+			continue
+		}
+		mode, modeOK := i2cModeFor(speed)
+		if speed > 0 && !modeOK {
+			out = append(out, withLocation(locs, base+".speed_khz", Finding{
+				Severity: SevError,
+				Code:     "I2C_SPEED_INVALID",
+				Message:  fmt.Sprintf("bus %s speed %.0fkHz exceeds fast mode plus (1000kHz)", bus.Name, speed),
+			}))
+			continue
+		}
+
+		if speed > 0 {
+			var nodes []i2cNode
+			for _, seg := range i2cSegments(spec, busIndex) {
+				nodes = append(nodes, seg.Nodes...)
+			}
+			for _, n := range nodes {
+				if n.MaxSpeedKHz > 0 && n.MaxSpeedKHz < speed {
+					out = append(out, withLocation(locs, n.Path, Finding{
+						Severity: SevError,
+						Code:     "I2C_DEVICE_SPEED",
+						Message: fmt.Sprintf(
+							"%s supports up to %.0fkHz but bus %s runs at %.0fkHz",
+							n.Name,
+							n.MaxSpeedKHz,
+							bus.Name,
+							speed,
+						),
+					}))
+				}
+			}
+		}
+
+		pullupV, pullupSource := i2cPullupV(spec, bus)
+		if pullupV > 0 && mcuLogicV > 0 && bus.PullupOhm > 0 {
+			switch {
+			case pullupV > mcuLogicV+0.3:
+				out = append(out, withLocation(locs, base+".pullup_voltage_v", Finding{
+					Severity: SevError,
+					Code:     "I2C_PULLUP_V_MISMATCH",
+					Message: fmt.Sprintf(
+						"bus %s pull-ups to %.2fV (%s) exceed MCU logic %.2fV. Pull up to the MCU rail or add a level shifter.",
+						bus.Name,
+						pullupV,
+						pullupSource,
+						mcuLogicV,
+					),
+				}))
+			case pullupV < 0.7*mcuLogicV:
+				out = append(out, withLocation(locs, base+".pullup_voltage_v", Finding{
+					Severity: SevWarn,
+					Code:     "I2C_PULLUP_V_MISMATCH",
+					Message: fmt.Sprintf(
+						"bus %s pull-ups to %.2fV (%s) are below 0.7 x MCU logic %.2fV. The MCU may not read a high level.",
+						bus.Name,
+						pullupV,
+						pullupSource,
+						mcuLogicV,
+					),
+				}))
+			}
+		}
+
+		if bus.PullupOhm <= 0 || speed == 0 {
+			continue
+		}
+		if pullupV > i2cVOL {
+			sinkMA := (pullupV - i2cVOL) / bus.PullupOhm * 1000
+			if sinkMA > mode.SinkMA {
+				out = append(out, withLocation(locs, base+".pullup_ohm", Finding{
+					Severity: SevError,
+					Code:     "I2C_PULLUP_TOO_STRONG",
+					Message: fmt.Sprintf(
+						"bus %s pull-up %.0fΩ to %.2fV needs %.2fmA to pull low, above the %.0fmA %s limit. Use at least %.0fΩ.",
+						bus.Name,
+						bus.PullupOhm,
+						pullupV,
+						sinkMA,
+						mode.SinkMA,
+						mode.Name,
+						math.Ceil((pullupV-i2cVOL)/mode.SinkMA*1000),
+					),
+				}))
+			}
+		}
+
+		capPF := i2cBusCapacitancePF(spec, busIndex)
+		if capPF <= 0 {
+			continue
+		}
+		if capPF > mode.MaxCapPF {
+			out = append(out, withLocation(locs, base, Finding{
+				Severity: SevError,
+				Code:     "I2C_BUS_CAPACITANCE",
+				Message: fmt.Sprintf(
+					"bus %s capacitance %.0fpF exceeds the %.0fpF %s limit",
+					bus.Name,
+					capPF,
+					mode.MaxCapPF,
+					mode.Name,
+				),
+			}))
+		}
+		riseNs := i2cRiseNs(bus.PullupOhm, capPF)
+		maxOhm := mode.RiseNs * 1000 / (0.8473 * capPF)
+		switch {
+		case riseNs > mode.RiseNs:
+			out = append(out, withLocation(locs, base+".pullup_ohm", Finding{
+				Severity: SevError,
+				Code:     "I2C_RISE_TIME",
+				Message: fmt.Sprintf(
+					"bus %s rise time %.0fns (%.0fΩ x %.0fpF) exceeds %.0fns for %s at %.0fkHz. Use at most %.0fΩ or lower the speed.",
+					bus.Name,
+					riseNs,
+					bus.PullupOhm,
+					capPF,
+					mode.RiseNs,
+					mode.Name,
+					speed,
+					math.Floor(maxOhm),
+				),
+			}))
+		case riseNs >= 0.8*mode.RiseNs:
+			out = append(out, withLocation(locs, base+".pullup_ohm", Finding{
+				Severity: SevWarn,
+				Code:     "I2C_RISE_TIME_MARGIN",
+				Message: fmt.Sprintf(
+					"bus %s rise time %.0fns (%.0fΩ x %.0fpF) is close to the %.0fns %s limit",
+					bus.Name,
+					riseNs,
+					bus.PullupOhm,
+					capPF,
+					mode.RiseNs,
+					mode.Name,
+				),
+			}))
+		default:
+			out = append(out, withLocation(locs, base, Finding{
+				Severity: SevInfo,
+				Code:     "I2C_TIMING_OK",
+				Message: fmt.Sprintf(
+					"bus %s at %.0fkHz: rise time %.0fns (%.0fΩ x %.0fpF) within %.0fns",
+					bus.Name,
+					speed,
+					riseNs,
+					bus.PullupOhm,
+					capPF,
+					mode.RiseNs,
+				),
+			}))
+		}
+	}
+	return out
+}
@@ -0,0 +1,379 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/badimirzai/robotics-verifier-cli/internal/model"
+)
+
+func i2cSpec() model.RobotSpec {
+	spec := baseSpec()
+	spec.MCU.LogicVoltageV = 3.3
+	spec.Power.Rail = model.Rail{VoltageV: 3.3, MaxCurrentA: 1}
+	spec.Power.Rails = []model.PowerRail{
+		{Name: "periph_5v", Source: "battery", Regulator: "buck", VoltageV: 5, Efficiency: 0.9, MaxCurrentA: 1},
+	}
+	spec.Driver.LogicVoltageMinV = 2.7
+	spec.I2CBuses = []model.I2CBus{{
+		Name:          "i2c0",
+		SpeedKHz:      400,
+		PullupOhm:     2200,
+		CapacitancePF: 50,
+		Devices: []model.I2CDevice{
+			{Name: "imu", AddressHex: 0x68, PinCapacitancePF: 10, MaxSpeedKHz: 400},
+		},
+	}}
+	spec.PWM = []model.PWMController{{
+		Name: "pwm0", Bus: "i2c0", AddressHex: 0x40, Channels: 16,
+		LogicVoltageMinV: 2.3, LogicVoltageMaxV: 5.5,
+		PinCapacitancePF: 6, MaxSpeedKHz: 1000,
+	}}
+	return spec
+}
+
+func TestRuleI2CElectrical(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*model.RobotSpec)
+		want   []string
+		not    []string
+	}{
+		{
+			name:   "fast_mode_with_2k2",
+			mutate: func(s *model.RobotSpec) {},
+			want:   []string{"I2C_TIMING_OK"},
+			not: []string{
+				"I2C_RISE_TIME", "I2C_RISE_TIME_MARGIN", "I2C_BUS_CAPACITANCE", "I2C_PULLUP_TOO_STRONG",
+				"I2C_DEVICE_SPEED", "I2C_SPEED_INVALID", "I2C_PULLUP_V_MISMATCH",
+			},
+		},
+		{
+			name: "weak_pullup_too_slow_for_fast_mode",
+			mutate: func(s *model.RobotSpec) {
+				s.I2CBuses[0].PullupOhm = 10000
+			},
+			want: []string{"I2C_RISE_TIME"},
+			not:  []string{"I2C_TIMING_OK"},
+		},
+		{
+			name: "weak_pullup_fine_in_standard_mode",
+			mutate: func(s *model.RobotSpec) {
+				s.I2CBuses[0].SpeedKHz = 100
+				s.I2CBuses[0].PullupOhm = 10000
+			},
+			want: []string{"I2C_TIMING_OK"},
+			not:  []string{"I2C_RISE_TIME", "I2C_RISE_TIME_MARGIN"},
+		},
+		{
+			name: "rise_time_close_to_limit",
+			mutate: func(s *model.RobotSpec) {
+				s.I2CBuses[0].PullupOhm = 4700
+			},
+			want: []string{"I2C_RISE_TIME_MARGIN"},
+			not:  []string{"I2C_RISE_TIME"},
+		},
+		{
+			name: "long_cable_from_trace_length",
+			mutate: func(s *model.RobotSpec) {
+				s.I2CBuses[0].CapacitancePF = 0
+				s.I2CBuses[0].TraceLengthCm = 500
+				s.I2CBuses[0].PullupOhm = 470
+			},
+			want: []string{"I2C_BUS_CAPACITANCE"},
+		},
+		{
+			name: "pullup_sinks_more_than_3ma",
+			mutate: func(s *model.RobotSpec) {
+				s.I2CBuses[0].PullupOhm = 680
+			},
+			want: []string{"I2C_PULLUP_TOO_STRONG"},
+		},
+		{
+			name: "strong_pullup_allowed_in_fast_mode_plus",
+			mutate: func(s *model.RobotSpec) {
+				s.I2CBuses[0].SpeedKHz = 1000
+				s.I2CBuses[0].PullupOhm = 680
+			},
+			want: []string{"I2C_DEVICE_SPEED"},
+			not:  []string{"I2C_PULLUP_TOO_STRONG", "I2C_RISE_TIME"},
+		},
+		{
+			name: "speed_above_fast_mode_plus",
+			mutate: func(s *model.RobotSpec) {
+				s.I2CBuses[0].SpeedKHz = 3400
+			},
+			want: []string{"I2C_SPEED_INVALID"},
+		},
+		{
+			name: "pullups_on_5v_rail_with_3v3_mcu",
+			mutate: func(s *model.RobotSpec) {
+				s.I2CBuses[0].Rail = "periph_5v"
+			},
+			want: []string{"I2C_PULLUP_V_MISMATCH"},
+		},
+		{
+			name: "pullups_on_1v8",
+			mutate: func(s *model.RobotSpec) {
+				s.I2CBuses[0].PullupVoltageV = 1.8
+			},
+			want: []string{"I2C_PULLUP_V_MISMATCH"},
+		},
+		{
+			name: "no_pullup_declared",
+			mutate: func(s *model.RobotSpec) {
+				s.I2CBuses[0].PullupOhm = 0
+			},
+			not: []string{"I2C_TIMING_OK", "I2C_RISE_TIME", "I2C_PULLUP_V_MISMATCH"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := i2cSpec()
+			tt.mutate(&spec)
+			codes := reportCodes(RunAll(spec, nil))
+			for _, c := range tt.want {
+				requireHasCode(t, codes, c)
+			}
+			for _, c := range tt.not {
+				requireNoCode(t, codes, c)
+			}
+		})
+	}
+}
+
+func TestI2CRiseTime(t *testing.T) {
+	spec := i2cSpec()
+	if got := i2cBusCapacitancePF(spec, 0); got != 66 {
+		t.Fatalf("expected 66pF bus capacitance, got %.1f", got)
+	}
+	spec.I2CBuses[0].CapacitancePF = 0
+	spec.I2CBuses[0].TraceLengthCm = 30
+	if got := i2cBusCapacitancePF(spec, 0); got != 46 {
+		t.Fatalf("expected 46pF from trace length, got %.1f", got)
+	}
+	// 4.7k with 100pF is the classic 398ns fast mode failure.
+	if got := i2cRiseNs(4700, 100); got < 398 || got > 399 {
+		t.Fatalf("expected ~398ns rise time, got %.1f", got)
+	}
+}
+
+func mpu6050(name string) model.I2CDevice {
+	return model.I2CDevice{
+		Name:       name,
+		AddressHex: 0x68,
+		Addresses: []model.I2CAddressOption{
+			{AddressHex: 0x68, Strap: "AD0 low"},
+			{AddressHex: 0x69, Strap: "AD0 high"},
+		},
+	}
+}
+
+func TestRuleI2CAddressConflictSuggestions(t *testing.T) {
+	tests := []struct {
+		name    string
+		devices []model.I2CDevice
+		action  string
+		moves   map[string]string
+	}{
+		{
+			name:    "second_imu_strapped_high",
+			devices: []model.I2CDevice{mpu6050("imu_left"), mpu6050("imu_right")},
+			action:  "reassign",
+			moves:   map[string]string{"imu_right": "0x69"},
+		},
+		{
+			name:    "three_imus_need_a_mux",
+			devices: []model.I2CDevice{mpu6050("imu_a"), mpu6050("imu_b"), mpu6050("imu_c")},
+			action:  "add_mux",
+		},
+		{
+			name:    "fixed_devices_need_a_mux",
+			devices: []model.I2CDevice{{Name: "a", AddressHex: 0x68}, {Name: "b", AddressHex: 0x68}},
+			action:  "add_mux",
+		},
+		{
+			name:    "fixed_device_keeps_its_address",
+			devices: []model.I2CDevice{{Name: "clock", AddressHex: 0x68}, mpu6050("imu")},
+			action:  "reassign",
+			moves:   map[string]string{"imu": "0x69"},
+		},
+		{
+			name:    "strappable_device_moves_for_fixed_one",
+			devices: []model.I2CDevice{mpu6050("imu"), {Name: "clock", AddressHex: 0x68}},
+			action:  "reassign",
+			moves:   map[string]string{"imu": "0x69"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := baseSpec()
+			spec.I2CBuses = []model.I2CBus{{Name: "i2c0", Devices: tt.devices}}
+			var found *Finding
+			for _, f := range RunAll(spec, nil).Findings {
+				if f.Code == "I2C_ADDR_CONFLICT" {
+					f := f
+					found = &f
+					break
+				}
+			}
+			if found == nil {
+				t.Fatalf("expected I2C_ADDR_CONFLICT")
+			}
+			fixIt, ok := found.Meta["fix_it"].(map[string]any)
+			if !ok {
+				t.Fatalf("expected fix_it meta, got %#v", found.Meta)
+			}
+			if fixIt["action"] != tt.action {
+				t.Fatalf("expected action %q, got %#v", tt.action, fixIt)
+			}
+			if tt.moves == nil {
+				return
+			}
+			changes, _ := fixIt["changes"].([]map[string]any)
+			if len(changes) != len(tt.moves) {
+				t.Fatalf("expected %d change(s), got %#v", len(tt.moves), changes)
+			}
+			for _, c := range changes {
+				if tt.moves[c["device"].(string)] != c["address"] {
+					t.Fatalf("unexpected change %#v", c)
+				}
+			}
+		})
+	}
+}
+
+func TestSuggestI2CAddressesMovesOnlyWhenNeeded(t *testing.T) {
+	// imu_b clashes with imu_a and its alternate is held by the fixed imu_c, so the
+	// device that was first on 0x68 has to move instead.
+	nodes := []i2cNode{
+		{Name: "imu_a", Address: 0x68, Addresses: []model.I2CAddressOption{{AddressHex: 0x68}, {AddressHex: 0x6A}}},
+		{Name: "imu_b", Address: 0x68, Addresses: []model.I2CAddressOption{{AddressHex: 0x68}, {AddressHex: 0x69}}},
+		{Name: "imu_c", Address: 0x69},
+	}
+	moves, ok := suggestI2CAddresses(nodes)
+	if !ok {
+		t.Fatalf("expected an assignment")
+	}
+	if len(moves) != 1 || moves[0].Node.Name != "imu_a" || moves[0].Address != 0x6A {
+		t.Fatalf("expected imu_a to move to 0x6A, got %+v", moves)
+	}
+}
+
+func vl53l0x(name string) model.I2CDevice {
+	return model.I2CDevice{Name: name, AddressHex: 0x29}
+}
+
+func muxSpec() model.RobotSpec {
+	spec := baseSpec()
+	spec.I2CBuses = []model.I2CBus{{
+		Name: "i2c0",
+		Devices: []model.I2CDevice{
+			{Name: "imu", AddressHex: 0x68},
+			{
+				Name:        "tof_mux",
+				AddressHex:  0x70,
+				MuxChannels: 8,
+				Segments: []model.I2CSegment{
+					{Channel: 0, Devices: []model.I2CDevice{vl53l0x("tof_left")}},
+					{Channel: 1, Devices: []model.I2CDevice{vl53l0x("tof_right")}},
+				},
+			},
+		},
+	}}
+	return spec
+}
+
+func TestRuleI2CTopology(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*model.RobotSpec)
+		want   []string
+		not    []string
+	}{
+		{
+			name:   "identical_sensors_on_separate_channels",
+			mutate: func(s *model.RobotSpec) {},
+			not:    []string{"I2C_ADDR_CONFLICT", "I2C_ADDR_RESERVED", "I2C_MUX_CHANNEL"},
+		},
+		{
+			name: "identical_sensors_on_one_channel",
+			mutate: func(s *model.RobotSpec) {
+				seg := &s.I2CBuses[0].Devices[1].Segments[0]
+				seg.Devices = append(seg.Devices, vl53l0x("tof_rear"))
+			},
+			want: []string{"I2C_ADDR_CONFLICT"},
+		},
+		{
+			name: "downstream_clashes_with_parent_bus",
+			mutate: func(s *model.RobotSpec) {
+				seg := &s.I2CBuses[0].Devices[1].Segments[1]
+				seg.Devices = append(seg.Devices, model.I2CDevice{Name: "imu2", AddressHex: 0x68})
+			},
+			want: []string{"I2C_ADDR_CONFLICT"},
+		},
+		{
+			name: "mux_address_counts_on_parent",
+			mutate: func(s *model.RobotSpec) {
+				s.I2CBuses[0].Devices[0].AddressHex = 0x70
+			},
+			want: []string{"I2C_ADDR_CONFLICT"},
+		},
+		{
+			name: "reserved_low_address",
+			mutate: func(s *model.RobotSpec) {
+				s.I2CBuses[0].Devices[0].AddressHex = 0x03
+			},
+			want: []string{"I2C_ADDR_RESERVED"},
+		},
+		{
+			name: "reserved_high_address_behind_mux",
+			mutate: func(s *model.RobotSpec) {
+				s.I2CBuses[0].Devices[1].Segments[0].Devices[0].AddressHex = 0x7A
+			},
+			want: []string{"I2C_ADDR_RESERVED"},
+		},
+		{
+			name: "channel_beyond_mux",
+			mutate: func(s *model.RobotSpec) {
+				s.I2CBuses[0].Devices[1].Segments[1].Channel = 8
+			},
+			want: []string{"I2C_MUX_CHANNEL"},
+		},
+		{
+			name: "channel_listed_twice",
+			mutate: func(s *model.RobotSpec) {
+				s.I2CBuses[0].Devices[1].Segments[1].Channel = 0
+			},
+			want: []string{"I2C_MUX_CHANNEL", "I2C_ADDR_CONFLICT"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := muxSpec()
+			tt.mutate(&spec)
+			codes := reportCodes(RunAll(spec, nil))
+			for _, c := range tt.want {
+				requireHasCode(t, codes, c)
+			}
+			for _, c := range tt.not {
+				requireNoCode(t, codes, c)
+			}
+		})
+	}
+}
+
+func TestI2CSegments(t *testing.T) {
+	segs := i2cSegments(muxSpec(), 0)
+	if len(segs) != 3 {
+		t.Fatalf("expected bus plus two segments, got %d", len(segs))
+	}
+	if segs[1].Name != "i2c0/tof_mux ch0" || segs[1].Path != "i2c_buses[0].devices[1].segments[0]" {
+		t.Fatalf("unexpected segment %+v", segs[1])
+	}
+	if len(segs[2].Upstream) != 2 {
+		t.Fatalf("expected imu and mux upstream, got %+v", segs[2].Upstream)
+	}
+}
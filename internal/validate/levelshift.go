@@ -0,0 +1,251 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/badimirzai/robotics-verifier-cli/internal/model"
+)
+
+// Shifter directions. A is the MCU side, B the peripheral side.
+const (
+	shiftBidirectional = "bidirectional"
+	shiftAToB          = "a_to_b"
+	shiftBToA          = "b_to_a"
+)
+
+// shiftTarget is the peripheral at the B end of a shifted signal.
+type shiftTarget struct {
+	Label    string
+	LevelV   float64 // the peripheral's IO level, 0 when unknown
+	Needs    string  // the direction its signals travel
+	RateMbps float64 // fastest signal, 0 when unknown
+	Lines    int     // signals the route carries, 0 when unknown
+}
+
+// shiftRoute is one signal passing through a level shifter.
+type shiftRoute struct {
+	Shifter model.LevelShifter
+	Signal  model.ShiftedSignal
+	Path    string
+}
+
+func shiftTargetFor(spec model.RobotSpec, name string) (shiftTarget, bool) {
+	for _, e := range driverEntries(spec) {
+		if name != e.Path && (e.Driver.Name == "" || name != e.Driver.Name) {
+			continue
+		}
+		// PWM and two direction inputs per channel.
+		t := shiftTarget{Label: e.Label(), Needs: shiftAToB, Lines: 3 * e.Driver.Channels}
+		if rail, ok := logicSupply(spec, e.Driver.LogicRail); ok {
+			t.LevelV = rail.VoltageV
+		}
+		return t, true
+	}
+	for _, u := range spec.UARTs {
+		if u.Name == "" || name != u.Name {
+			continue
+		}
+		// The device TX into the MCU RX is the line that needs translating; MCU TX
+		// into a 5V TTL input usually reads fine.
+		t := shiftTarget{Label: u.Name, LevelV: u.LogicVoltageV, Needs: shiftBToA, Lines: 2}
+		if t.LevelV == 0 && u.Rail != "" {
+			if rail, ok := namedSupply(spec, u.Rail); ok {
+				t.LevelV = rail.VoltageV
+			}
+		}
+		if u.Baud > 0 {
+			t.RateMbps = float64(u.Baud) / 1e6
+		}
+		return t, true
+	}
+	for i, st := range spec.Steppers {
+		if name != fmt.Sprintf("steppers[%d]", i) && (st.Name == "" || name != st.Name) {
+			continue
+		}
+		// STEP and DIR per driver.
+		t := shiftTarget{Label: "stepper " + st.Name + " driver", Needs: shiftAToB, Lines: 2 * max(st.Count, 1)}
+		if rail, ok := logicSupply(spec, st.Driver.LogicRail); ok {
+			t.LevelV = rail.VoltageV
+		}
+		return t, true
+	}
+	for busIndex, bus := range spec.SPIBuses {
+		for devIndex, d := range bus.Devices {
+			if d.Name == "" || name != d.Name {
+				continue
+			}
+			// SCK, MOSI and CS out, MISO back.
+			t := shiftTarget{Label: d.Name, Needs: shiftBidirectional, Lines: 4}
+			if rail, ok := logicSupply(spec, spiDeviceRail(bus, d)); ok {
+				t.LevelV = rail.VoltageV
+			}
+			t.RateMbps, _ = spiClockMHz(bus, busIndex, devIndex)
+			return t, true
+		}
+	}
+	for _, e := range spec.Encoders {
+		if e.Name == "" || name != e.Name {
+			continue
+		}
+		highV, _ := encoderHighV(spec, e)
+		return shiftTarget{Label: "encoder " + e.Name, LevelV: highV, Needs: shiftBToA, Lines: 2}, true
+	}
+	return shiftTarget{}, false
+}
+
+// routeLines is the number of shifter channels a route uses.
+func routeLines(sig model.ShiftedSignal, t shiftTarget) int {
+	if sig.Lines > 0 {
+		return sig.Lines
+	}
+	return t.Lines
+}
+
+// shiftRouteTo returns the shifter a peripheral's signals pass through, if any.
+func shiftRouteTo(spec model.RobotSpec, names ...string) (shiftRoute, bool) {
+	for i, ls := range spec.Shifters {
+		for j, sig := range ls.Signals {
+			for _, name := range names {
+				if name != "" && sig.To == name {
+					return shiftRoute{ls, sig, fmt.Sprintf("level_shifters[%d].signals[%d]", i, j)}, true
+				}
+			}
+		}
+	}
+	return shiftRoute{}, false
+}
+
+func shiftDirection(ls model.LevelShifter) string {
+	d := strings.ToLower(strings.TrimSpace(ls.Direction))
+	if d == "" {
+		return shiftBidirectional
+	}
+	return d
+}
+
+func shiftCarries(ls model.LevelShifter, needs string) bool {
+	d := shiftDirection(ls)
+	return d == shiftBidirectional || d == needs
+}
+
+// shiftSides returns the A and B side voltages of a route. The A side follows MCU
+// logic and the B side the peripheral unless the shifter names its rails.
+func shiftSides(spec model.RobotSpec, ls model.LevelShifter, t shiftTarget) (float64, float64) {
+	aV, bV := spec.MCU.LogicVoltageV, t.LevelV
+	if ls.ARail != "" {
+		if rail, ok := namedSupply(spec, ls.ARail); ok {
+			aV = rail.VoltageV
+		}
+	}
+	if ls.BRail != "" {
+		if rail, ok := namedSupply(spec, ls.BRail); ok {
+			bV = rail.VoltageV
+		}
+	}
+	return aV, bV
+}
+
+func withinV(v, minV, maxV float64) bool {
+	return (minV <= 0 || v >= minV) && (maxV <= 0 || v <= maxV)
+}
+
+// routeProblems lists what stops a shifter from translating a peripheral's signals.
+func routeProblems(spec model.RobotSpec, r shiftRoute, t shiftTarget) []string {
+	ls := r.Shifter
+	aV, bV := shiftSides(spec, ls, t)
+	var out []string
+	if aV > 0 && !withinV(aV, ls.ASideMinV, ls.ASideMaxV) {
+		out = append(out, fmt.Sprintf("A side %.2fV is outside %s", aV, voltageWindow(ls.ASideMinV, ls.ASideMaxV)))
+	}
+	if bV > 0 && !withinV(bV, ls.BSideMinV, ls.BSideMaxV) {
+		out = append(out, fmt.Sprintf("B side %.2fV is outside %s", bV, voltageWindow(ls.BSideMinV, ls.BSideMaxV)))
+	}
+	if !shiftCarries(ls, t.Needs) {
+		out = append(out, fmt.Sprintf("it only passes %s but %s needs %s", shiftDirection(ls), t.Label, t.Needs))
+	}
+	return out
+}
+
+func ruleLevelShifters(spec model.RobotSpec, locs map[string]Location) []Finding {
+	var out []Finding
+	for i, ls := range spec.Shifters {
+		base := fmt.Sprintf("level_shifters[%d]", i)
+		switch shiftDirection(ls) {
+		case shiftBidirectional, shiftAToB, shiftBToA:
+		default:
+			out = append(out, withLocation(locs, base+".direction", Finding{
+				Severity: SevError,
+				Code:     "LEVEL_SHIFTER_DIRECTION_INVALID",
+				Message:  fmt.Sprintf("%s direction %q must be bidirectional, a_to_b or b_to_a", ls.Name, ls.Direction),
+			}))
+			continue
+		}
+
+		lines := 0
+		var routed []string
+		for j, sig := range ls.Signals {
+			path := fmt.Sprintf("%s.signals[%d]", base, j)
+			t, ok := shiftTargetFor(spec, sig.To)
+			if ok && routeLines(sig, t) > 0 {
+				lines += routeLines(sig, t)
+				routed = append(routed, fmt.Sprintf("%d to %s", routeLines(sig, t), t.Label))
+			}
+			if !ok {
+				out = append(out, withLocation(locs, path+".to", Finding{
+					Severity: SevError,
+					Code:     "LEVEL_SHIFTER_SIGNAL_UNKNOWN",
+					Message:  fmt.Sprintf("%s routes signals to %q, which is not a motor driver, stepper, UART, SPI device or encoder", ls.Name, sig.To),
+				}))
+				continue
+			}
+			if problems := routeProblems(spec, shiftRoute{ls, sig, path}, t); len(problems) > 0 {
+				out = append(out, withLocation(locs, path, Finding{
+					Severity: SevError,
+					Code:     "LEVEL_SHIFTER_ROUTE",
+					Message:  fmt.Sprintf("%s cannot translate signals to %s: %s", ls.Name, t.Label, strings.Join(problems, "; ")),
+				}))
+			}
+
+			rate := sig.DataRateMbps
+			if rate == 0 {
+				rate = t.RateMbps
+			}
+			if ls.MaxDataRateMbps > 0 && rate > ls.MaxDataRateMbps {
+				out = append(out, withLocation(locs, path, Finding{
+					Severity: SevError,
+					Code:     "LEVEL_SHIFTER_SPEED",
+					Message: fmt.Sprintf(
+						"%s signals at %.3gMbps exceed %s maximum of %.3gMbps",
+						t.Label,
+						rate,
+						ls.Name,
+						ls.MaxDataRateMbps,
+					),
+				}))
+			}
+		}
+		if ls.Channels > 0 && lines > ls.Channels {
+			out = append(out, withLocation(locs, base+".signals", Finding{
+				Severity: SevError,
+				Code:     "LEVEL_SHIFTER_CHANNELS",
+				Message: fmt.Sprintf(
+					"%s routes %d signals (%s) but has %d channels. Add a shifter or set lines on the routes that share pins.",
+					ls.Name, lines, strings.Join(routed, ", "), ls.Channels,
+				),
+			}))
+		}
+	}
+	return out
+}
+
+// shiftStatus reports whether a peripheral's signals pass through a shifter and
+// whether that shifter can translate them.
+func shiftStatus(spec model.RobotSpec, names ...string) (r shiftRoute, routed, works bool) {
+	r, routed = shiftRouteTo(spec, names...)
+	if !routed {
+		return r, false, false
+	}
+	t, ok := shiftTargetFor(spec, r.Signal.To)
+	return r, true, ok && len(routeProblems(spec, r, t)) == 0
+}
@@ -0,0 +1,233 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/badimirzai/robotics-verifier-cli/internal/model"
+)
+
+// shifterSpec drives a 5V only motor driver from a 3.3V MCU through a TXS0108E
+// style shifter.
+func shifterSpec() model.RobotSpec {
+	spec := baseSpec()
+	spec.MCU.LogicVoltageV = 3.3
+	spec.Power.Rails = []model.PowerRail{
+		{Name: "3v3", Source: "battery", Regulator: "buck", VoltageV: 3.3, Efficiency: 0.9, MaxCurrentA: 1},
+	}
+	spec.Shifters = []model.LevelShifter{{
+		Name:      "ls0",
+		ASideMinV: 1.2, ASideMaxV: 3.6,
+		BSideMinV: 1.65, BSideMaxV: 5.5,
+		Direction:       "bidirectional",
+		MaxDataRateMbps: 110,
+		Signals:         []model.ShiftedSignal{{To: "motor_driver"}},
+	}}
+	return spec
+}
+
+func TestRuleLevelShifters(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*model.RobotSpec)
+		want   []string
+		not    []string
+	}{
+		{
+			name:   "mismatch_resolved_via_shifter",
+			mutate: func(s *model.RobotSpec) {},
+			want:   []string{"LOGIC_LEVEL_SHIFTED"},
+			not:    []string{"LOGIC_LEVEL_MISMATCH", "LOGIC_V_MCU_MISMATCH", "LEVEL_SHIFTER_ROUTE", "LEVEL_SHIFTER_SPEED"},
+		},
+		{
+			name: "no_shifter",
+			mutate: func(s *model.RobotSpec) {
+				s.Shifters = nil
+			},
+			want: []string{"LOGIC_LEVEL_MISMATCH"},
+			not:  []string{"LOGIC_LEVEL_SHIFTED"},
+		},
+		{
+			name: "driver_named_in_list",
+			mutate: func(s *model.RobotSpec) {
+				s.Driver.Name = "left"
+				s.Shifters[0].Signals[0].To = "left"
+			},
+			want: []string{"LOGIC_LEVEL_SHIFTED"},
+			not:  []string{"LOGIC_LEVEL_MISMATCH"},
+		},
+		{
+			name: "mcu_above_a_side",
+			mutate: func(s *model.RobotSpec) {
+				s.Shifters[0].ASideMaxV = 1.8
+			},
+			want: []string{"LEVEL_SHIFTER_ROUTE", "LOGIC_LEVEL_MISMATCH"},
+			not:  []string{"LOGIC_LEVEL_SHIFTED"},
+		},
+		{
+			name: "wrong_direction",
+			mutate: func(s *model.RobotSpec) {
+				s.Shifters[0].Direction = "b_to_a"
+			},
+			want: []string{"LEVEL_SHIFTER_ROUTE", "LOGIC_LEVEL_MISMATCH"},
+		},
+		{
+			name: "b_side_on_3v3_still_too_low",
+			mutate: func(s *model.RobotSpec) {
+				s.Shifters[0].BRail = "3v3"
+			},
+			want: []string{"LOGIC_LEVEL_MISMATCH"},
+			not:  []string{"LOGIC_LEVEL_SHIFTED", "LEVEL_SHIFTER_ROUTE"},
+		},
+		{
+			name: "rail_difference_resolved_via_shifter",
+			mutate: func(s *model.RobotSpec) {
+				s.Driver.LogicVoltageMinV = 2.7
+			},
+			want: []string{"LOGIC_LEVEL_SHIFTED"},
+			not:  []string{"LOGIC_V_MCU_MISMATCH", "LOGIC_LEVEL_MISMATCH"},
+		},
+		{
+			name: "unknown_signal_target",
+			mutate: func(s *model.RobotSpec) {
+				s.Shifters[0].Signals[0].To = "arm"
+			},
+			want: []string{"LEVEL_SHIFTER_SIGNAL_UNKNOWN", "LOGIC_LEVEL_MISMATCH"},
+		},
+		{
+			name: "invalid_direction",
+			mutate: func(s *model.RobotSpec) {
+				s.Shifters[0].Direction = "both"
+			},
+			want: []string{"LEVEL_SHIFTER_DIRECTION_INVALID"},
+		},
+		{
+			name: "too_fast_for_bss138",
+			mutate: func(s *model.RobotSpec) {
+				s.Shifters[0].MaxDataRateMbps = 2
+				s.Shifters[0].Signals[0].DataRateMbps = 5
+			},
+			want: []string{"LEVEL_SHIFTER_SPEED"},
+		},
+		{
+			name: "5v_uart_through_shifter",
+			mutate: func(s *model.RobotSpec) {
+				s.UARTs = []model.UART{{Name: "gps", Instance: "UART1", Baud: 115200, LogicVoltageV: 5}}
+				s.Shifters[0].Signals = append(s.Shifters[0].Signals, model.ShiftedSignal{To: "gps"})
+			},
+			want: []string{"LOGIC_LEVEL_SHIFTED"},
+			not:  []string{"UART_LOGIC_MISMATCH", "LEVEL_SHIFTER_SPEED"},
+		},
+		{
+			name: "5v_uart_through_one_way_buffer",
+			mutate: func(s *model.RobotSpec) {
+				s.UARTs = []model.UART{{Name: "gps", Instance: "UART1", Baud: 115200, LogicVoltageV: 5}}
+				s.Shifters[0].Direction = "a_to_b"
+				s.Shifters[0].Signals = []model.ShiftedSignal{{To: "gps"}}
+			},
+			want: []string{"UART_LOGIC_MISMATCH", "LEVEL_SHIFTER_ROUTE"},
+		},
+		{
+			name: "5v_spi_device_through_shifter",
+			mutate: func(s *model.RobotSpec) {
+				s.SPIBuses = []model.SPIBus{{Name: "spi0", ClockMHz: 1, Devices: []model.SPIDevice{
+					{Name: "adc", CSPin: "D10", LogicVoltageMinV: 4.5, LogicVoltageMaxV: 5.5},
+				}}}
+				s.Driver.LogicVoltageMinV = 2.7
+				s.Shifters[0].Signals = []model.ShiftedSignal{{To: "adc"}}
+			},
+			want: []string{"LOGIC_LEVEL_SHIFTED"},
+			not:  []string{"SPI_LOGIC_MISMATCH", "LEVEL_SHIFTER_SIGNAL_UNKNOWN"},
+		},
+		{
+			name: "5v_spi_device_without_shifter",
+			mutate: func(s *model.RobotSpec) {
+				s.SPIBuses = []model.SPIBus{{Name: "spi0", ClockMHz: 1, Devices: []model.SPIDevice{
+					{Name: "adc", CSPin: "D10", LogicVoltageMinV: 4.5, LogicVoltageMaxV: 5.5},
+				}}}
+			},
+			want: []string{"SPI_LOGIC_MISMATCH"},
+		},
+		{
+			name: "spi_device_through_one_way_buffer",
+			mutate: func(s *model.RobotSpec) {
+				s.SPIBuses = []model.SPIBus{{Name: "spi0", ClockMHz: 1, Devices: []model.SPIDevice{
+					{Name: "adc", CSPin: "D10", LogicVoltageMinV: 4.5, LogicVoltageMaxV: 5.5},
+				}}}
+				s.Shifters[0].Direction = "a_to_b"
+				s.Shifters[0].Signals = []model.ShiftedSignal{{To: "adc"}}
+			},
+			want: []string{"SPI_LOGIC_MISMATCH", "LEVEL_SHIFTER_ROUTE"},
+		},
+		{
+			name: "stepper_driver_through_shifter",
+			mutate: func(s *model.RobotSpec) {
+				s.Shifters[0].Signals = []model.ShiftedSignal{{To: "arm"}}
+				s.Driver.LogicVoltageMinV = 2.7
+				s.Steppers = []model.Stepper{{
+					Name: "arm", Count: 1,
+					Driver: model.StepperDriver{LogicVoltageMinV: 4.5, LogicVoltageMaxV: 5.5},
+				}}
+			},
+			want: []string{"LOGIC_LEVEL_SHIFTED"},
+			not:  []string{"LOGIC_LEVEL_MISMATCH", "LEVEL_SHIFTER_SIGNAL_UNKNOWN"},
+		},
+		{
+			name: "stepper_driver_without_shifter",
+			mutate: func(s *model.RobotSpec) {
+				s.Shifters = nil
+				s.Driver.LogicVoltageMinV = 2.7
+				s.Steppers = []model.Stepper{{
+					Name: "arm", Count: 1,
+					Driver: model.StepperDriver{LogicVoltageMinV: 4.5, LogicVoltageMaxV: 5.5},
+				}}
+			},
+			want: []string{"LOGIC_LEVEL_MISMATCH"},
+		},
+		{
+			name: "5v_encoder_through_shifter",
+			mutate: func(s *model.RobotSpec) {
+				s.Encoders = []model.Encoder{{Name: "left_enc", Motor: "M", MotorEncoder: model.MotorEncoder{Output: "push_pull"}}}
+				s.Driver.LogicVoltageMinV = 2.7
+				s.Shifters[0].Signals = []model.ShiftedSignal{{To: "left_enc"}}
+			},
+			want: []string{"LOGIC_LEVEL_SHIFTED"},
+			not:  []string{"ENCODER_LEVEL_MISMATCH", "LEVEL_SHIFTER_SIGNAL_UNKNOWN"},
+		},
+		{
+			name: "5v_encoder_without_shifter",
+			mutate: func(s *model.RobotSpec) {
+				s.Encoders = []model.Encoder{{Name: "left_enc", Motor: "M", MotorEncoder: model.MotorEncoder{Output: "push_pull"}}}
+			},
+			want: []string{"ENCODER_LEVEL_MISMATCH"},
+		},
+		{
+			name: "more_signals_than_channels",
+			mutate: func(s *model.RobotSpec) {
+				s.Shifters[0].Channels = 4
+			},
+			want: []string{"LEVEL_SHIFTER_CHANNELS"},
+		},
+		{
+			name: "lines_override_fits_channels",
+			mutate: func(s *model.RobotSpec) {
+				s.Shifters[0].Channels = 4
+				s.Shifters[0].Signals[0].Lines = 4
+			},
+			not: []string{"LEVEL_SHIFTER_CHANNELS"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := shifterSpec()
+			tt.mutate(&spec)
+			codes := reportCodes(RunAll(spec, nil))
+			for _, c := range tt.want {
+				requireHasCode(t, codes, c)
+			}
+			for _, c := range tt.not {
+				requireNoCode(t, codes, c)
+			}
+		})
+	}
+}
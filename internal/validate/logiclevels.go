@@ -0,0 +1,188 @@
+package validate
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/badimirzai/robotics-verifier-cli/internal/model"
+)
+
+// minNoiseMarginV is the margin TTL guarantees on each side (2.4V VOH against 2.0V
+// VIH, 0.8V VIL against 0.4V VOL). Less than that still works on a bench but not next
+// to a switching motor bridge.
+const minNoiseMarginV = 0.4
+
+// fiveVTolerantMaxV is the input limit of 5V tolerant pins.
+const fiveVTolerantMaxV = 5.5
+
+func hasOutputLevels(l model.IOLevels) bool { return l.VOHV > 0 }
+func hasInputLevels(l model.IOLevels) bool  { return l.VIHV > 0 }
+
+// mcuFiveVTolerant reports whether the MCU's IO pins accept 5V inputs.
+func mcuFiveVTolerant(mcu model.MCU) bool {
+	return mcu.FiveVTolerant != nil && *mcu.FiveVTolerant
+}
+
+// mcuIOGroup returns the MCU io group a peripheral connects to: the named one, else
+// the first. The MCU wide five_v_tolerant flag, when set, applies to every group.
+func mcuIOGroup(mcu model.MCU, name string) (model.IOLevels, bool) {
+	for _, g := range mcu.IOGroups {
+		if name == "" || g.Name == name {
+			if mcu.FiveVTolerant != nil {
+				g.FiveVTolerant = *mcu.FiveVTolerant
+			}
+			return g, true
+		}
+	}
+	return model.IOLevels{}, false
+}
+
+// inputMaxV is the highest voltage an input accepts: 5.5V for 5V tolerant pins, else
+// the declared limit, else the receiver supply plus ioOverdriveV.
+func inputMaxV(in model.IOLevels, supplyV float64) float64 {
+	switch {
+	case in.FiveVTolerant:
+		return fiveVTolerantMaxV
+	case in.MaxInputV > 0:
+		return in.MaxInputV
+	case supplyV > 0:
+		return supplyV + ioOverdriveV
+	}
+	return 0
+}
+
+// ioLink is one signal direction between a driving output and a receiving input.
+type ioLink struct {
+	From, To   string
+	Out, In    model.IOLevels
+	OutSupplyV float64 // how high the output actually swings
+	InSupplyV  float64 // receiver supply, for the input limit when none is declared
+	Path       string
+}
+
+func (l ioLink) known() bool { return hasOutputLevels(l.Out) && hasInputLevels(l.In) }
+
+// ioLinks lists every MCU to peripheral and peripheral to MCU signal whose levels are
+// known on both ends. Signals through a level shifter are checked by the shifter rules.
+func ioLinks(spec model.RobotSpec) []ioLink {
+	mcu := spec.MCU
+	var out []ioLink
+	for _, e := range driverEntries(spec) {
+		if _, routed := shiftRouteTo(spec, e.Path, e.Driver.Name); routed {
+			continue
+		}
+		group, ok := mcuIOGroup(mcu, e.Driver.MCUIOGroup)
+		if !ok {
+			continue
+		}
+		l := ioLink{
+			From: "MCU", To: e.Label() + " inputs",
+			Out: group, In: e.Driver.LogicInputs,
+			OutSupplyV: mcu.LogicVoltageV,
+			Path:       e.Path + ".logic_inputs",
+		}
+		if rail, ok := logicSupply(spec, e.Driver.LogicRail); ok {
+			l.InSupplyV = rail.VoltageV
+		}
+		if l.known() {
+			out = append(out, l)
+		}
+	}
+	for i, u := range spec.UARTs {
+		if _, routed := shiftRouteTo(spec, u.Name); routed {
+			continue
+		}
+		group, ok := mcuIOGroup(mcu, u.MCUIOGroup)
+		if !ok {
+			continue
+		}
+		path := fmt.Sprintf("uarts[%d].io", i)
+		links := []ioLink{
+			{From: "MCU TX", To: u.Name + " RX", Out: group, In: u.IO, OutSupplyV: mcu.LogicVoltageV, InSupplyV: u.LogicVoltageV, Path: path},
+			{From: u.Name + " TX", To: "MCU RX", Out: u.IO, In: group, OutSupplyV: u.LogicVoltageV, InSupplyV: mcu.LogicVoltageV, Path: path},
+		}
+		for _, l := range links {
+			if l.known() {
+				out = append(out, l)
+			}
+		}
+	}
+	return out
+}
+
+// driverLevelsKnown reports whether ruleLogicThresholds covers the MCU to driver signals,
+// which then replaces the logic supply window comparison.
+func driverLevelsKnown(spec model.RobotSpec, e driverEntry) bool {
+	group, ok := mcuIOGroup(spec.MCU, e.Driver.MCUIOGroup)
+	return ok && hasOutputLevels(group) && hasInputLevels(e.Driver.LogicInputs)
+}
+
+// uartRXLevelsKnown reports whether ruleLogicThresholds covers a UART device's TX line.
+func uartRXLevelsKnown(spec model.RobotSpec, u model.UART) bool {
+	group, ok := mcuIOGroup(spec.MCU, u.MCUIOGroup)
+	return ok && hasInputLevels(group) && hasOutputLevels(u.IO)
+}
+
+// roundMilli keeps derived values in meta free of float noise.
+func roundMilli(v float64) float64 { return math.Round(v*1000) / 1000 }
+
+func ruleLogicThresholds(spec model.RobotSpec, locs map[string]Location) []Finding {
+	var out []Finding
+	for _, l := range ioLinks(spec) {
+		nmHigh := l.Out.VOHV - l.In.VIHV
+		nmLow := l.In.VILV - l.Out.VOLV
+		meta := map[string]any{
+			"from":                l.From,
+			"to":                  l.To,
+			"noise_margin_high_v": roundMilli(nmHigh),
+			"noise_margin_low_v":  roundMilli(nmLow),
+		}
+		levels := fmt.Sprintf(
+			"VOH %.2fV / VIH %.2fV, VOL %.2fV / VIL %.2fV",
+			l.Out.VOHV, l.In.VIHV, l.Out.VOLV, l.In.VILV,
+		)
+
+		if maxV := inputMaxV(l.In, l.InSupplyV); maxV > 0 && l.OutSupplyV > maxV {
+			out = append(out, withLocation(locs, l.Path, Finding{
+				Severity: SevError,
+				Code:     "LOGIC_INPUT_OVERVOLTAGE",
+				Message: fmt.Sprintf(
+					"%s swings to %.2fV, above the %.2fV %s can take. Add a level shifter or a divider.",
+					l.From, l.OutSupplyV, maxV, l.To,
+				),
+				Meta: meta,
+			}))
+		}
+
+		switch {
+		case nmHigh < 0 || nmLow < 0:
+			out = append(out, withLocation(locs, l.Path, Finding{
+				Severity: SevError,
+				Code:     "LOGIC_THRESHOLD_FAIL",
+				Message: fmt.Sprintf(
+					"%s cannot drive %s reliably (%s): noise margin high %.2fV, low %.2fV",
+					l.From, l.To, levels, nmHigh, nmLow,
+				),
+				Meta: meta,
+			}))
+		case nmHigh < minNoiseMarginV || nmLow < minNoiseMarginV:
+			out = append(out, withLocation(locs, l.Path, Finding{
+				Severity: SevWarn,
+				Code:     "LOGIC_NOISE_MARGIN_LOW",
+				Message: fmt.Sprintf(
+					"%s to %s noise margin high %.2fV, low %.2fV is below %.1fV (%s); keep the wires short or add a buffer",
+					l.From, l.To, nmHigh, nmLow, minNoiseMarginV, levels,
+				),
+				Meta: meta,
+			}))
+		default:
+			out = append(out, withLocation(locs, l.Path, Finding{
+				Severity: SevInfo,
+				Code:     "LOGIC_NOISE_MARGIN_OK",
+				Message:  fmt.Sprintf("%s to %s noise margin high %.2fV, low %.2fV (%s)", l.From, l.To, nmHigh, nmLow, levels),
+				Meta:     meta,
+			}))
+		}
+	}
+	return out
+}
@@ -0,0 +1,156 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/badimirzai/robotics-verifier-cli/internal/model"
+)
+
+var (
+	esp32GPIO  = model.IOLevels{Name: "gpio", VOHV: 2.64, VOLV: 0.33, VIHV: 2.475, VILV: 0.825, MaxInputV: 3.6}
+	ttlInputs  = model.IOLevels{VIHV: 2.0, VILV: 0.8}
+	l298Inputs = model.IOLevels{VIHV: 2.3, VILV: 1.5, MaxInputV: 7}
+)
+
+// thresholdSpec drives 5V logic supply driver inputs from a 3.3V MCU; the logic
+// supply window alone would call it a mismatch.
+func thresholdSpec() model.RobotSpec {
+	spec := baseSpec()
+	spec.MCU.LogicVoltageV = 3.3
+	spec.MCU.IOGroups = []model.IOLevels{esp32GPIO}
+	spec.Driver.LogicInputs = ttlInputs
+	return spec
+}
+
+func TestRuleLogicThresholds(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*model.RobotSpec)
+		want   []string
+		not    []string
+	}{
+		{
+			name:   "3v3_mcu_drives_ttl_inputs",
+			mutate: func(s *model.RobotSpec) {},
+			want:   []string{"LOGIC_NOISE_MARGIN_OK"},
+			not:    []string{"LOGIC_LEVEL_MISMATCH", "LOGIC_V_MCU_MISMATCH", "LOGIC_THRESHOLD_FAIL", "LOGIC_INPUT_OVERVOLTAGE"},
+		},
+		{
+			name: "l298_inputs_are_marginal",
+			mutate: func(s *model.RobotSpec) {
+				s.Driver.LogicInputs = l298Inputs
+			},
+			want: []string{"LOGIC_NOISE_MARGIN_LOW"},
+			not:  []string{"LOGIC_THRESHOLD_FAIL", "LOGIC_LEVEL_MISMATCH"},
+		},
+		{
+			name: "5v_cmos_inputs_do_not_see_high",
+			mutate: func(s *model.RobotSpec) {
+				s.Driver.LogicInputs = model.IOLevels{VIHV: 3.5, VILV: 1.5}
+			},
+			want: []string{"LOGIC_THRESHOLD_FAIL"},
+		},
+		{
+			name: "5v_mcu_into_3v3_driver_inputs",
+			mutate: func(s *model.RobotSpec) {
+				s.MCU.LogicVoltageV = 5
+				s.MCU.IOGroups = []model.IOLevels{{VOHV: 4.2, VOLV: 0.9, VIHV: 3, VILV: 1.5}}
+				s.Power.Rail.VoltageV = 3.3
+				s.Driver.LogicVoltageMinV = 2.7
+			},
+			want: []string{"LOGIC_INPUT_OVERVOLTAGE"},
+		},
+		{
+			name: "5v_mcu_into_tolerant_driver_inputs",
+			mutate: func(s *model.RobotSpec) {
+				s.MCU.LogicVoltageV = 5
+				s.MCU.IOGroups = []model.IOLevels{{VOHV: 4.2, VOLV: 0.9, VIHV: 3, VILV: 1.5}}
+				s.Power.Rail.VoltageV = 3.3
+				s.Driver.LogicVoltageMinV = 2.7
+				s.Driver.LogicInputs.FiveVTolerant = true
+			},
+			not: []string{"LOGIC_INPUT_OVERVOLTAGE"},
+		},
+		{
+			name: "no_driver_thresholds_uses_supply_window",
+			mutate: func(s *model.RobotSpec) {
+				s.Driver.LogicInputs = model.IOLevels{}
+			},
+			want: []string{"LOGIC_LEVEL_MISMATCH"},
+			not:  []string{"LOGIC_NOISE_MARGIN_OK"},
+		},
+		{
+			name: "named_io_group",
+			mutate: func(s *model.RobotSpec) {
+				s.MCU.IOGroups = append(s.MCU.IOGroups, model.IOLevels{Name: "weak", VOHV: 2.2, VOLV: 0.5, VIHV: 2.475, VILV: 0.825})
+				s.Driver.MCUIOGroup = "weak"
+			},
+			want: []string{"LOGIC_NOISE_MARGIN_LOW"},
+		},
+		{
+			name: "5v_uart_tx_into_3v3_rx",
+			mutate: func(s *model.RobotSpec) {
+				s.UARTs = []model.UART{{
+					Name: "gps", Instance: "UART1", LogicVoltageV: 5,
+					IO: model.IOLevels{VOHV: 4.4, VOLV: 0.4, VIHV: 2.0, VILV: 0.8, MaxInputV: 5.5},
+				}}
+			},
+			want: []string{"LOGIC_INPUT_OVERVOLTAGE"},
+			not:  []string{"UART_LOGIC_MISMATCH"},
+		},
+		{
+			name: "5v_uart_tx_into_tolerant_rx",
+			mutate: func(s *model.RobotSpec) {
+				s.MCU.FiveVTolerant = boolPtr(true)
+				s.UARTs = []model.UART{{
+					Name: "gps", Instance: "UART1", LogicVoltageV: 5,
+					IO: model.IOLevels{VOHV: 4.4, VOLV: 0.4, VIHV: 2.0, VILV: 0.8, MaxInputV: 5.5},
+				}}
+			},
+			not: []string{"LOGIC_INPUT_OVERVOLTAGE", "UART_LOGIC_MISMATCH"},
+		},
+		{
+			name: "explicit_false_overrides_tolerant_group",
+			mutate: func(s *model.RobotSpec) {
+				group := esp32GPIO
+				group.FiveVTolerant = true
+				s.MCU.IOGroups = []model.IOLevels{group}
+				s.MCU.FiveVTolerant = boolPtr(false)
+				s.UARTs = []model.UART{{
+					Name: "gps", Instance: "UART1", LogicVoltageV: 5,
+					IO: model.IOLevels{VOHV: 4.4, VOLV: 0.4, VIHV: 2.0, VILV: 0.8, MaxInputV: 5.5},
+				}}
+			},
+			want: []string{"LOGIC_INPUT_OVERVOLTAGE"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := thresholdSpec()
+			tt.mutate(&spec)
+			codes := reportCodes(RunAll(spec, nil))
+			for _, c := range tt.want {
+				requireHasCode(t, codes, c)
+			}
+			for _, c := range tt.not {
+				requireNoCode(t, codes, c)
+			}
+		})
+	}
+}
+
+func TestRuleLogicThresholds_MetaMargins(t *testing.T) {
+	spec := thresholdSpec()
+	spec.Driver.LogicInputs = l298Inputs
+	for _, f := range ruleLogicThresholds(spec, nil) {
+		if f.Code != "LOGIC_NOISE_MARGIN_LOW" {
+			continue
+		}
+		if f.Meta["noise_margin_high_v"] != 0.34 || f.Meta["noise_margin_low_v"] != 1.17 {
+			t.Fatalf("unexpected margins in meta: %v", f.Meta)
+		}
+		return
+	}
+	t.Fatalf("expected LOGIC_NOISE_MARGIN_LOW")
+}
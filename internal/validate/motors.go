@@ -0,0 +1,157 @@
+package validate
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/badimirzai/robotics-verifier-cli/internal/model"
+)
+
+// bridgeDrop splits the voltage a driver channel loses into a fixed part and a series
+// resistance. Bipolar bridges drop a saturation voltage that grows with current;
+// MOSFET bridges conduct through a high and a low side Rds(on).
+func bridgeDrop(d model.MotorDriver) (fixedV, ohms float64) {
+	if d.SaturationDropV > 0 || d.SaturationResistanceOhm > 0 {
+		return d.SaturationDropV, d.SaturationResistanceOhm
+	}
+	return 0, 2 * d.RdsOnOhm
+}
+
+func hasBridgeDrop(d model.MotorDriver) bool {
+	fixedV, ohms := bridgeDrop(d)
+	return fixedV > 0 || ohms > 0
+}
+
+// driverDropV is the voltage a driver loses across its bridge while carrying currentA
+// split over the given number of paralleled channels.
+func driverDropV(d model.MotorDriver, currentA float64, channels int) float64 {
+	if channels < 1 {
+		channels = 1
+	}
+	fixedV, ohms := bridgeDrop(d)
+	return fixedV + ohms*currentA/float64(channels)
+}
+
+// motorDuty returns the PWM duty cap as a fraction.
+func motorDuty(m model.Motor) float64 {
+	if m.MaxDutyPct > 0 {
+		return m.MaxDutyPct / 100
+	}
+	return 1
+}
+
+// ruleMotorVoltage compares the voltage each DC motor sees, its driver supply minus
+// the bridge drop at nominal current and scaled by the PWM duty cap, with the motor
+// rating. Each motor is reported once per driver it is wired to.
+func ruleMotorVoltage(spec model.RobotSpec, locs map[string]Location) []Finding {
+	var out []Finding
+	invalid := make(map[int]bool)
+	for i, m := range spec.Motors {
+		if m.MaxDutyPct < 0 || m.MaxDutyPct > 100 {
+			invalid[i] = true
+			out = append(out, withLocation(locs, fmt.Sprintf("motors[%d].max_duty_pct", i), Finding{
+				Severity: SevError,
+				Code:     "MOTOR_DUTY_INVALID",
+				Message:  fmt.Sprintf("motors[%d].max_duty_pct must be in (0, 100]", i),
+			}))
+		}
+	}
+
+	entries := driverEntries(spec)
+	seen := make(map[[2]int]bool)
+	for _, iw := range wireInstances(spec, entries) {
+		m := iw.Motor
+		base := fmt.Sprintf("motors[%d]", iw.MotorIndex)
+		key := [2]int{iw.MotorIndex, iw.Driver}
+		if iw.Driver < 0 || invalid[iw.MotorIndex] || seen[key] || (m.VoltageMinV <= 0 && m.VoltageMaxV <= 0) {
+			continue
+		}
+		seen[key] = true
+		e := entries[iw.Driver]
+		supply, ok := motorSupply(spec, e.Driver.SupplyRail)
+		if !ok || supply.VoltageV <= 0 {
+			continue
+		}
+
+		dropV := driverDropV(e.Driver, m.NominalCurrentA, len(iw.Channels))
+		motorV := (supply.VoltageV - dropV) * motorDuty(m)
+		detail := fmt.Sprintf("%s %.2fV", supply.Label(), supply.VoltageV)
+		if dropV > 0 {
+			detail += fmt.Sprintf(" - %.2fV %s drop", dropV, e.Label())
+		}
+		if m.MaxDutyPct > 0 && m.MaxDutyPct < 100 {
+			detail = fmt.Sprintf("(%s) x %.0f%% duty", detail, m.MaxDutyPct)
+		}
+
+		switch {
+		case m.VoltageMaxV > 0 && motorV > m.VoltageMaxV:
+			out = append(out, withLocation(locs, base, Finding{
+				Severity: SevError,
+				Code:     "MOTOR_OVERVOLTAGE",
+				Message: fmt.Sprintf(
+					"motor %s sees %.2fV (%s), above its %.2fV maximum. Limit the PWM duty with max_duty_pct or lower the supply.",
+					m.Name,
+					motorV,
+					detail,
+					m.VoltageMaxV,
+				),
+			}))
+		case m.VoltageMinV > 0 && motorV < m.VoltageMinV:
+			out = append(out, withLocation(locs, base, Finding{
+				Severity: SevWarn,
+				Code:     "MOTOR_UNDERVOLTAGE",
+				Message: fmt.Sprintf(
+					"motor %s sees %.2fV (%s), below its %.2fV minimum. Expect reduced speed and torque.",
+					m.Name,
+					motorV,
+					detail,
+					m.VoltageMinV,
+				),
+			}))
+		}
+	}
+	return out
+}
+
+// ruleBridgeLoss reports how much of the supply each driver with a declared bridge
+// drop passes on to its motors at nominal load.
+func ruleBridgeLoss(spec model.RobotSpec, locs map[string]Location) []Finding {
+	var out []Finding
+	for _, alloc := range allocateDrivers(spec) {
+		drv := alloc.Entry.Driver
+		supplyV := alloc.supplyV(spec)
+		if !hasBridgeDrop(drv) || supplyV <= 0 {
+			continue
+		}
+		worstDropV, worstA, lossW, motorW := 0.0, 0.0, 0.0, 0.0
+		for _, g := range alloc.Channels {
+			currentA := g.nominalA()
+			if currentA <= 0 {
+				continue
+			}
+			dropV := math.Min(driverDropV(drv, currentA, len(g.Channels)), supplyV)
+			lossW += dropV * currentA
+			motorW += (supplyV - dropV) * currentA
+			if dropV > worstDropV {
+				worstDropV, worstA = dropV, currentA
+			}
+		}
+		if lossW <= 0 {
+			continue
+		}
+		out = append(out, withLocation(locs, alloc.Entry.Path, Finding{
+			Severity: SevInfo,
+			Code:     "DRV_BRIDGE_LOSS",
+			Message: fmt.Sprintf(
+				"%s bridge drops up to %.2fV at %.2fA nominal; %.0f%% of the %.2fV supply reaches the motors, %.2fW lost in the driver",
+				alloc.Entry.Label(),
+				worstDropV,
+				worstA,
+				100*motorW/(motorW+lossW),
+				supplyV,
+				lossW,
+			),
+		}))
+	}
+	return out
+}
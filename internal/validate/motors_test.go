@@ -0,0 +1,196 @@
+package validate
+
+import (
+	"math"
+	"testing"
+
+	"github.com/badimirzai/robotics-verifier-cli/internal/model"
+)
+
+func TestRuleMotorVoltage(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*model.RobotSpec)
+		want   []string
+		not    []string
+	}{
+		{
+			name: "rated_for_supply",
+			mutate: func(s *model.RobotSpec) {
+				s.Motors[0].VoltageMinV = 6
+				s.Motors[0].VoltageMaxV = 12
+			},
+			not: []string{"MOTOR_OVERVOLTAGE", "MOTOR_UNDERVOLTAGE", "MOTOR_DUTY_INVALID"},
+		},
+		{
+			name: "6v_motor_on_12v",
+			mutate: func(s *model.RobotSpec) {
+				s.Motors[0].VoltageMinV = 3
+				s.Motors[0].VoltageMaxV = 6
+			},
+			want: []string{"MOTOR_OVERVOLTAGE"},
+		},
+		{
+			name: "bipolar_drop_brings_it_in_range",
+			mutate: func(s *model.RobotSpec) {
+				s.Motors[0].VoltageMinV = 6
+				s.Motors[0].VoltageMaxV = 10
+				s.Driver.SaturationDropV = 2
+			},
+			not: []string{"MOTOR_OVERVOLTAGE", "MOTOR_UNDERVOLTAGE"},
+		},
+		{
+			name: "duty_cap",
+			mutate: func(s *model.RobotSpec) {
+				s.Motors[0].VoltageMinV = 3
+				s.Motors[0].VoltageMaxV = 6
+				s.Motors[0].MaxDutyPct = 50
+			},
+			not: []string{"MOTOR_OVERVOLTAGE", "MOTOR_UNDERVOLTAGE"},
+		},
+		{
+			name: "duty_cap_too_low",
+			mutate: func(s *model.RobotSpec) {
+				s.Motors[0].VoltageMinV = 6
+				s.Motors[0].VoltageMaxV = 12
+				s.Motors[0].MaxDutyPct = 40
+			},
+			want: []string{"MOTOR_UNDERVOLTAGE"},
+		},
+		{
+			name: "duty_out_of_range",
+			mutate: func(s *model.RobotSpec) {
+				s.Motors[0].VoltageMaxV = 12
+				s.Motors[0].MaxDutyPct = 120
+			},
+			want: []string{"MOTOR_DUTY_INVALID"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := baseSpec()
+			tt.mutate(&spec)
+			codes := reportCodes(RunAll(spec, nil))
+			for _, code := range tt.want {
+				requireHasCode(t, codes, code)
+			}
+			for _, code := range tt.not {
+				requireNoCode(t, codes, code)
+			}
+		})
+	}
+}
+
+func TestDriverDropV(t *testing.T) {
+	mosfet := model.MotorDriver{RdsOnOhm: 0.5}
+	if got := driverDropV(mosfet, 1, 1); math.Abs(got-1) > 1e-9 {
+		t.Fatalf("single channel drop = %.3fV, want 1V", got)
+	}
+	if got := driverDropV(mosfet, 1, 2); math.Abs(got-0.5) > 1e-9 {
+		t.Fatalf("paralleled drop = %.3fV, want 0.5V", got)
+	}
+	if got := driverDropV(model.MotorDriver{SaturationDropV: 2, RdsOnOhm: 0.5}, 1, 1); got != 2 {
+		t.Fatalf("bipolar drop = %.3fV, want 2V", got)
+	}
+}
+
+func TestMotorVoltageLocation(t *testing.T) {
+	spec := baseSpec()
+	spec.Motors[0].VoltageMaxV = 6
+	locs := map[string]Location{"motors[0]": {File: "robot.yaml", Line: 20, Column: 5}}
+	for _, f := range RunAll(spec, locs).Findings {
+		if f.Code != "MOTOR_OVERVOLTAGE" {
+			continue
+		}
+		if f.Path != "motors[0]" || f.Location == nil || f.Location.Line != 20 {
+			t.Fatalf("unexpected location: path %q, %+v", f.Path, f.Location)
+		}
+		return
+	}
+	t.Fatalf("expected MOTOR_OVERVOLTAGE")
+}
+
+// l298Spec drives two 12V gearmotors from a bipolar bridge with a current dependent drop.
+func l298Spec() model.RobotSpec {
+	spec := baseSpec()
+	spec.Driver.PeakPerChA = 2
+	spec.Driver.SaturationDropV = 1.2
+	spec.Driver.SaturationResistanceOhm = 1.4
+	spec.Motors[0].StallCurrentA = 2.5
+	spec.Motors[0].NominalCurrentA = 0.9
+	return spec
+}
+
+func TestBridgeDropAtTerminals(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*model.RobotSpec)
+		want   []string
+		not    []string
+	}{
+		{
+			name:   "datasheet_stall_without_rated_voltage",
+			mutate: func(s *model.RobotSpec) {},
+			want:   []string{"DRV_PEAK_LT_STALL", "DRV_BRIDGE_LOSS"},
+		},
+		{
+			name: "bridge_limits_stall_current",
+			mutate: func(s *model.RobotSpec) {
+				s.Motors[0].RatedVoltageV = 12
+			},
+			want: []string{"DRV_BRIDGE_LOSS"},
+			not:  []string{"DRV_PEAK_LT_STALL"},
+		},
+		{
+			name: "overdriven_motor_stalls_harder",
+			mutate: func(s *model.RobotSpec) {
+				s.Motors[0].RatedVoltageV = 6
+				s.Motors[0].StallCurrentA = 2
+			},
+			want: []string{"DRV_PEAK_LT_STALL"},
+		},
+		{
+			name: "no_drop_declared",
+			mutate: func(s *model.RobotSpec) {
+				s.Driver.SaturationDropV = 0
+				s.Driver.SaturationResistanceOhm = 0
+			},
+			not: []string{"DRV_BRIDGE_LOSS"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := l298Spec()
+			tt.mutate(&spec)
+			codes := reportCodes(RunAll(spec, nil))
+			for _, code := range tt.want {
+				requireHasCode(t, codes, code)
+			}
+			for _, code := range tt.not {
+				requireNoCode(t, codes, code)
+			}
+		})
+	}
+}
+
+func TestChannelStallAtTerminals(t *testing.T) {
+	d := model.MotorDriver{SaturationDropV: 1.2, SaturationResistanceOhm: 1.4}
+	m := model.Motor{StallCurrentA: 2.5, RatedVoltageV: 12}
+	g := channelLoad{Channels: []int{1}, Motors: []motorInstance{{Motor: m}}}
+	// 12V / 2.5A = 4.8Ω winding in series with the 1.4Ω bridge after the 1.2V drop.
+	if got, want := g.stallA(d, 12), 10.8/6.2; math.Abs(got-want) > 1e-9 {
+		t.Fatalf("stall = %.3fA, want %.3fA", got, want)
+	}
+	// Two motors on one channel share the bridge resistance.
+	g.Motors = append(g.Motors, motorInstance{Motor: m})
+	if got, want := g.stallA(d, 12), 10.8/3.8; math.Abs(got-want) > 1e-9 {
+		t.Fatalf("shared stall = %.3fA, want %.3fA", got, want)
+	}
+	// Without a rated voltage the datasheet values add up.
+	g.Motors[1].Motor.RatedVoltageV = 0
+	if got := g.stallA(d, 12); got != 5 {
+		t.Fatalf("datasheet stall = %.3fA, want 5A", got)
+	}
+}
@@ -0,0 +1,230 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/badimirzai/robotics-verifier-cli/internal/model"
+)
+
+// pinFunctionCaps maps a pins[].function to the capability the MCU pin needs.
+var pinFunctionCaps = map[string]string{
+	"pwm":       "pwm",
+	"adc":       "adc",
+	"i2c":       "i2c",
+	"spi":       "spi",
+	"uart":      "uart",
+	"output":    "gpio",
+	"input":     "gpio",
+	"interrupt": "interrupt",
+}
+
+// pinUsage is one claim on an MCU pin, from pins[] or implied elsewhere in the spec.
+type pinUsage struct {
+	Pin        string
+	Function   string
+	Label      string
+	Path       string
+	CurrentmA  float64
+	ChipSelect bool // SPI chip selects are checked for duplicates by ruleSPI
+}
+
+func pinKey(name string) string {
+	return strings.ToUpper(strings.TrimSpace(name))
+}
+
+func pinUsages(spec model.RobotSpec) []pinUsage {
+	var out []pinUsage
+	for i, p := range spec.Pins {
+		out = append(out, pinUsage{
+			Pin:       pinKey(p.Pin),
+			Function:  strings.ToLower(strings.TrimSpace(p.Function)),
+			Label:     p.Use,
+			Path:      fmt.Sprintf("pins[%d]", i),
+			CurrentmA: p.CurrentmA,
+		})
+	}
+	for i, bus := range spec.SPIBuses {
+		for j, d := range bus.Devices {
+			if strings.TrimSpace(d.CSPin) == "" {
+				continue
+			}
+			out = append(out, pinUsage{
+				Pin:        pinKey(d.CSPin),
+				Function:   "output",
+				Label:      d.Name + " chip select",
+				Path:       fmt.Sprintf("spi_buses[%d].devices[%d].cs_pin", i, j),
+				ChipSelect: true,
+			})
+		}
+	}
+	for i, e := range spec.Encoders {
+		for _, ch := range []struct{ name, pin string }{{"A", e.PinA}, {"B", e.PinB}} {
+			if strings.TrimSpace(ch.pin) == "" {
+				continue
+			}
+			out = append(out, pinUsage{
+				Pin:      pinKey(ch.pin),
+				Function: "interrupt",
+				Label:    fmt.Sprintf("%s encoder %s", e.Name, ch.name),
+				Path:     fmt.Sprintf("encoders[%d].pin_%s", i, strings.ToLower(ch.name)),
+			})
+		}
+	}
+	return out
+}
+
+func mcuPinTable(mcu model.MCU) map[string]model.MCUPin {
+	table := make(map[string]model.MCUPin, len(mcu.Pins))
+	for _, p := range mcu.Pins {
+		table[pinKey(p.Name)] = p
+	}
+	return table
+}
+
+func pinHasCap(p model.MCUPin, capability string) bool {
+	for _, c := range p.Caps {
+		if strings.EqualFold(c, capability) {
+			return true
+		}
+	}
+	return false
+}
+
+func (u pinUsage) label() string {
+	if u.Label != "" {
+		return u.Label
+	}
+	return u.Function
+}
+
+func rulePins(spec model.RobotSpec, locs map[string]Location) []Finding {
+	usages := pinUsages(spec)
+	if len(usages) == 0 {
+		return nil
+	}
+	var out []Finding
+	mcu := spec.MCU
+	table := mcuPinTable(mcu)
+
+	byPin := make(map[string][]pinUsage)
+	var order []string
+	var totalmA float64
+	for _, u := range usages {
+		if u.Pin == "" {
+			continue
+		}
+		if _, ok := byPin[u.Pin]; !ok {
+			order = append(order, u.Pin)
+		}
+		byPin[u.Pin] = append(byPin[u.Pin], u)
+		totalmA += u.CurrentmA
+
+		needed, known := pinFunctionCaps[u.Function]
+		if !known {
+			out = append(out, withLocation(locs, u.Path+".function", Finding{
+				Severity: SevError,
+				Code:     "PIN_FUNCTION_INVALID",
+				Message:  fmt.Sprintf("%s: unknown pin function %q (use pwm, output, input, interrupt, adc, i2c, spi or uart)", u.Path, u.Function),
+			}))
+		}
+
+		if mcu.MaxGPIOCurrentmA > 0 && u.CurrentmA > mcu.MaxGPIOCurrentmA {
+			out = append(out, withLocation(locs, u.Path+".current_ma", Finding{
+				Severity: SevError,
+				Code:     "PIN_CURRENT_OVER",
+				Message: fmt.Sprintf(
+					"%s (%s) carries %.1fmA, above the %.1fmA per pin limit",
+					u.Pin,
+					u.label(),
+					u.CurrentmA,
+					mcu.MaxGPIOCurrentmA,
+				),
+			}))
+		}
+
+		if len(table) == 0 {
+			continue
+		}
+		pin, ok := table[u.Pin]
+		if !ok {
+			out = append(out, withLocation(locs, u.Path, Finding{
+				Severity: SevError,
+				Code:     "PIN_UNKNOWN",
+				Message:  fmt.Sprintf("%s (%s) is not a pin on %s", u.Pin, u.label(), mcu.Name),
+			}))
+			continue
+		}
+		switch {
+		case known && !pinHasCap(pin, needed):
+			out = append(out, withLocation(locs, u.Path, Finding{
+				Severity: SevError,
+				Code:     "PIN_CAPABILITY",
+				Message:  fmt.Sprintf("%s (%s) cannot do %s (capabilities: %s)", u.Pin, u.label(), u.Function, strings.Join(pin.Caps, ", ")),
+			}))
+		case u.Function == "output" || u.Function == "pwm":
+			if pinHasCap(pin, "input_only") {
+				out = append(out, withLocation(locs, u.Path, Finding{
+					Severity: SevError,
+					Code:     "PIN_CAPABILITY",
+					Message:  fmt.Sprintf("%s (%s) is input only and cannot drive %s", u.Pin, u.label(), u.Function),
+				}))
+			}
+		}
+		if pinHasCap(pin, "strapping") {
+			note := ""
+			if pin.Note != "" {
+				note = " (" + pin.Note + ")"
+			}
+			out = append(out, withLocation(locs, u.Path, Finding{
+				Severity: SevWarn,
+				Code:     "PIN_STRAPPING",
+				Message: fmt.Sprintf(
+					"%s (%s) is a strapping pin%s. Whatever is attached must not pull it to the wrong level at reset; prefer a free pin.",
+					u.Pin,
+					u.label(),
+					note,
+				),
+			}))
+		}
+	}
+
+	for _, pin := range order {
+		users := byPin[pin]
+		if len(users) < 2 {
+			continue
+		}
+		allCS := true
+		labels := make([]string, len(users))
+		for i, u := range users {
+			labels[i] = u.label()
+			allCS = allCS && u.ChipSelect
+		}
+		if allCS {
+			continue
+		}
+		out = append(out, withLocation(locs, users[1].Path, Finding{
+			Severity: SevError,
+			Code:     "PIN_CONFLICT",
+			Message:  fmt.Sprintf("%s is assigned to %s", pin, strings.Join(labels, ", ")),
+		}))
+	}
+
+	if mcu.MaxTotalGPIOCurrentmA > 0 && totalmA > 0 {
+		switch {
+		case totalmA > mcu.MaxTotalGPIOCurrentmA:
+			out = append(out, withLocation(locs, "pins", Finding{
+				Severity: SevError,
+				Code:     "GPIO_TOTAL_CURRENT_OVER",
+				Message:  fmt.Sprintf("GPIO current %.1fmA across all pins exceeds the MCU total of %.1fmA", totalmA, mcu.MaxTotalGPIOCurrentmA),
+			}))
+		case totalmA >= 0.8*mcu.MaxTotalGPIOCurrentmA:
+			out = append(out, withLocation(locs, "pins", Finding{
+				Severity: SevWarn,
+				Code:     "GPIO_TOTAL_CURRENT_MARGIN_LOW",
+				Message:  fmt.Sprintf("GPIO current %.1fmA across all pins is close to the MCU total of %.1fmA", totalmA, mcu.MaxTotalGPIOCurrentmA),
+			}))
+		}
+	}
+	return out
+}
@@ -0,0 +1,158 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/badimirzai/robotics-verifier-cli/internal/model"
+)
+
+func pinSpec() model.RobotSpec {
+	spec := baseSpec()
+	spec.MCU.Name = "ESP32-S3"
+	spec.MCU.LogicVoltageV = 3.3
+	spec.MCU.MaxGPIOCurrentmA = 40
+	spec.MCU.MaxTotalGPIOCurrentmA = 100
+	spec.MCU.Pins = []model.MCUPin{
+		{Name: "GPIO0", Caps: []string{"gpio", "pwm", "strapping"}, Note: "boot mode"},
+		{Name: "GPIO4", Caps: []string{"gpio", "pwm", "adc"}},
+		{Name: "GPIO5", Caps: []string{"gpio", "pwm", "adc"}},
+		{Name: "GPIO10", Caps: []string{"gpio", "pwm", "spi"}},
+		{Name: "GPIO34", Caps: []string{"gpio", "adc", "input_only"}},
+		{Name: "D2", Caps: []string{"gpio"}},
+	}
+	spec.Power.Rail = model.Rail{VoltageV: 3.3, MaxCurrentA: 1}
+	spec.Driver.LogicVoltageMinV = 2.7
+	spec.Pins = []model.PinAssignment{
+		{Pin: "GPIO4", Function: "pwm", Use: "left PWMA", CurrentmA: 1},
+		{Pin: "GPIO5", Function: "output", Use: "left AIN1", CurrentmA: 1},
+		{Pin: "GPIO34", Function: "input", Use: "bumper"},
+	}
+	return spec
+}
+
+func TestRulePins(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*model.RobotSpec)
+		want   []string
+		not    []string
+	}{
+		{
+			name:   "driver_inputs_on_free_pins",
+			mutate: func(s *model.RobotSpec) {},
+			not: []string{
+				"PIN_CONFLICT", "PIN_UNKNOWN", "PIN_CAPABILITY", "PIN_STRAPPING", "PIN_FUNCTION_INVALID",
+				"PIN_CURRENT_OVER", "GPIO_TOTAL_CURRENT_OVER", "GPIO_TOTAL_CURRENT_MARGIN_LOW",
+			},
+		},
+		{
+			name: "pin_assigned_twice",
+			mutate: func(s *model.RobotSpec) {
+				s.Pins[1].Pin = "gpio4"
+			},
+			want: []string{"PIN_CONFLICT"},
+		},
+		{
+			name: "cs_line_on_driver_pin",
+			mutate: func(s *model.RobotSpec) {
+				s.SPIBuses = []model.SPIBus{{Name: "spi2", Devices: []model.SPIDevice{{Name: "imu", CSPin: "GPIO5"}}}}
+			},
+			want: []string{"PIN_CONFLICT"},
+		},
+		{
+			name: "duplicate_cs_left_to_spi_rule",
+			mutate: func(s *model.RobotSpec) {
+				s.SPIBuses = []model.SPIBus{{Name: "spi2", Devices: []model.SPIDevice{
+					{Name: "imu", CSPin: "GPIO10"},
+					{Name: "can", CSPin: "GPIO10"},
+				}}}
+			},
+			want: []string{"SPI_CS_DUPLICATE"},
+			not:  []string{"PIN_CONFLICT"},
+		},
+		{
+			name: "pwm_on_non_pwm_pin",
+			mutate: func(s *model.RobotSpec) {
+				s.Pins[0].Pin = "D2"
+			},
+			want: []string{"PIN_CAPABILITY"},
+		},
+		{
+			name: "output_on_input_only_pin",
+			mutate: func(s *model.RobotSpec) {
+				s.Pins[1].Pin = "GPIO34"
+				s.Pins[2].Pin = "GPIO5"
+			},
+			want: []string{"PIN_CAPABILITY"},
+		},
+		{
+			name: "strapping_pin",
+			mutate: func(s *model.RobotSpec) {
+				s.Pins[0].Pin = "GPIO0"
+			},
+			want: []string{"PIN_STRAPPING"},
+		},
+		{
+			name: "pin_not_on_mcu",
+			mutate: func(s *model.RobotSpec) {
+				s.Pins[0].Pin = "GPIO99"
+			},
+			want: []string{"PIN_UNKNOWN"},
+		},
+		{
+			name: "unknown_function",
+			mutate: func(s *model.RobotSpec) {
+				s.Pins[0].Function = "dac"
+			},
+			want: []string{"PIN_FUNCTION_INVALID"},
+		},
+		{
+			name: "led_over_pin_limit",
+			mutate: func(s *model.RobotSpec) {
+				s.Pins[1].CurrentmA = 45
+			},
+			want: []string{"PIN_CURRENT_OVER"},
+		},
+		{
+			name: "total_over_mcu_limit",
+			mutate: func(s *model.RobotSpec) {
+				s.Pins[0].CurrentmA = 35
+				s.Pins[1].CurrentmA = 35
+				s.Pins[2].CurrentmA = 35
+			},
+			want: []string{"GPIO_TOTAL_CURRENT_OVER"},
+			not:  []string{"PIN_CURRENT_OVER"},
+		},
+		{
+			name: "total_close_to_mcu_limit",
+			mutate: func(s *model.RobotSpec) {
+				s.Pins[0].CurrentmA = 30
+				s.Pins[1].CurrentmA = 30
+				s.Pins[2].CurrentmA = 25
+			},
+			want: []string{"GPIO_TOTAL_CURRENT_MARGIN_LOW"},
+		},
+		{
+			name: "no_pin_table_skips_capability_checks",
+			mutate: func(s *model.RobotSpec) {
+				s.MCU.Pins = nil
+				s.Pins[0].Pin = "D2"
+			},
+			not: []string{"PIN_CAPABILITY", "PIN_UNKNOWN"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := pinSpec()
+			tt.mutate(&spec)
+			codes := reportCodes(RunAll(spec, nil))
+			for _, c := range tt.want {
+				requireHasCode(t, codes, c)
+			}
+			for _, c := range tt.not {
+				requireNoCode(t, codes, c)
+			}
+		})
+	}
+}
@@ -0,0 +1,647 @@
+package validate
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/badimirzai/robotics-verifier-cli/internal/model"
+)
+
+// batterySource is the implicit root of the power tree.
+const batterySource = "battery"
+
+// supplyPoint is a resolved voltage source that a consumer is attached to.
+type supplyPoint struct {
+	Name        string
+	VoltageV    float64
+	MaxCurrentA float64
+	Path        string // YAML path of the voltage value
+	Legacy      bool   // power.logic_rail
+}
+
+// Label names the supply in finding messages.
+func (p supplyPoint) Label() string {
+	switch {
+	case p.Legacy:
+		return "logic rail"
+	case p.Name == batterySource:
+		return "battery"
+	default:
+		return fmt.Sprintf("rail %s", p.Name)
+	}
+}
+
+// logicSupply resolves the rail a logic consumer is attached to. Empty names keep
+// the single power.logic_rail so specs without a power tree behave as before.
+func logicSupply(spec model.RobotSpec, name string) (supplyPoint, bool) {
+	if name == "" {
+		return supplyPoint{
+			Name:        "logic_rail",
+			VoltageV:    spec.Power.Rail.VoltageV,
+			MaxCurrentA: spec.Power.Rail.MaxCurrentA,
+			Path:        "power.logic_rail.voltage_v",
+			Legacy:      true,
+		}, true
+	}
+	return namedSupply(spec, name)
+}
+
+// motorSupply resolves the rail feeding a driver motor supply. Empty names use the battery.
+func motorSupply(spec model.RobotSpec, name string) (supplyPoint, bool) {
+	if name == "" {
+		name = batterySource
+	}
+	return namedSupply(spec, name)
+}
+
+func namedSupply(spec model.RobotSpec, name string) (supplyPoint, bool) {
+	if name == batterySource {
+		return supplyPoint{
+			Name:     batterySource,
+			VoltageV: batteryNominalV(spec),
+			Path:     "power.battery.voltage_v",
+		}, true
+	}
+	idx, ok := findRail(spec, name)
+	if !ok {
+		return supplyPoint{}, false
+	}
+	return supplyPoint{
+		Name:        name,
+		VoltageV:    railOutputV(spec, idx),
+		MaxCurrentA: spec.Power.Rails[idx].MaxCurrentA,
+		Path:        fmt.Sprintf("power.rails[%d].voltage_v", idx),
+	}, true
+}
+
+func findRail(spec model.RobotSpec, name string) (int, bool) {
+	for i, rail := range spec.Power.Rails {
+		if rail.Name == name {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+func railSource(rail model.PowerRail) string {
+	if strings.TrimSpace(rail.Source) == "" {
+		return batterySource
+	}
+	return rail.Source
+}
+
+func railRegulator(rail model.PowerRail) string {
+	reg := strings.ToLower(strings.TrimSpace(rail.Regulator))
+	switch reg {
+	case "", "none", "switch":
+		return "none"
+	case "linear":
+		return "ldo"
+	case "buck-boost":
+		return "buck_boost"
+	}
+	return reg
+}
+
+// railOutputV returns the output voltage of a rail. Pass-through rails without an
+// explicit voltage inherit it from their source.
+func railOutputV(spec model.RobotSpec, idx int) float64 {
+	for depth := 0; depth <= len(spec.Power.Rails); depth++ {
+		rail := spec.Power.Rails[idx]
+		if rail.VoltageV != 0 || railRegulator(rail) != "none" {
+			return rail.VoltageV
+		}
+		src := railSource(rail)
+		if src == batterySource {
+			return batteryNominalV(spec)
+		}
+		next, ok := findRail(spec, src)
+		if !ok {
+			return 0
+		}
+		idx = next
+	}
+	return 0
+}
+
+// railInputV returns the voltage a rail regulator sees on its input.
+func railInputV(spec model.RobotSpec, rail model.PowerRail) float64 {
+	src, ok := namedSupply(spec, railSource(rail))
+	if !ok {
+		return 0
+	}
+	return src.VoltageV
+}
+
+// regulatorInputA converts a rail output current into the current drawn from its source.
+// Switching regulators conserve power (minus efficiency); linear and pass-through rails
+// draw the same current they deliver. Unset efficiency is treated as lossless.
+func regulatorInputA(rail model.PowerRail, vin, vout, iout float64) float64 {
+	switch railRegulator(rail) {
+	case "buck", "boost", "buck_boost":
+		eff := rail.Efficiency
+		if eff <= 0 || eff > 1 {
+			eff = 1
+		}
+		if vin <= 0 || vout <= 0 {
+			return iout
+		}
+		return vout * iout / (vin * eff)
+	}
+	return iout
+}
+
+// railLoad is the current drawn from a supply, split into continuous and peak demand.
+type railLoad struct {
+	ContinuousA float64
+	PeakA       float64
+}
+
+func (l railLoad) add(o railLoad) railLoad {
+	return railLoad{ContinuousA: l.ContinuousA + o.ContinuousA, PeakA: l.PeakA + o.PeakA}
+}
+
+// legacyLogicRail keys consumers of power.logic_rail in load maps. It is not part of
+// the power tree, so its demand never propagates to the battery.
+const legacyLogicRail = ""
+
+// logicConsumer is a logic-side load attached to a rail.
+type logicConsumer struct {
+	Label   string
+	Rail    string
+	Current model.SupplyCurrent
+}
+
+// logicConsumers lists the MCU, driver logic, PWM controllers, bus devices, encoders and
+// level shifters with the rail each draws from.
+func logicConsumers(spec model.RobotSpec) []logicConsumer {
+	out := []logicConsumer{
+		{Label: "MCU", Rail: spec.MCU.Rail, Current: spec.MCU.SupplyCurrent},
+	}
+	for _, e := range driverEntries(spec) {
+		out = append(out, logicConsumer{Label: e.Label() + " logic", Rail: e.Driver.LogicRail, Current: e.Driver.LogicCurrent})
+	}
+	for _, st := range spec.Steppers {
+		out = append(out, logicConsumer{
+			Label:   fmt.Sprintf("stepper %s driver logic", st.Name),
+			Rail:    st.Driver.LogicRail,
+			Current: scaleSupplyCurrent(st.Driver.LogicCurrent, st.Count),
+		})
+	}
+	for _, c := range spec.PWM {
+		out = append(out, logicConsumer{Label: c.Name, Rail: pwmControllerRail(spec, c), Current: c.SupplyCurrent})
+	}
+	for i, bus := range spec.I2CBuses {
+		for _, bd := range i2cBusDevices(bus, i) {
+			d := bd.Device
+			rail := d.Rail
+			if rail == "" {
+				rail = bus.Rail
+			}
+			out = append(out, logicConsumer{Label: d.Name, Rail: rail, Current: d.SupplyCurrent})
+		}
+	}
+	for _, bus := range spec.SPIBuses {
+		for _, d := range bus.Devices {
+			out = append(out, logicConsumer{Label: d.Name, Rail: spiDeviceRail(bus, d), Current: d.SupplyCurrent})
+		}
+	}
+	for _, u := range spec.UARTs {
+		out = append(out, logicConsumer{Label: u.Name, Rail: u.Rail, Current: u.SupplyCurrent})
+	}
+	for _, bus := range spec.CANBuses {
+		for _, n := range bus.Nodes {
+			out = append(out, logicConsumer{Label: n.Name, Rail: n.Rail, Current: n.SupplyCurrent})
+		}
+	}
+	for _, e := range spec.Encoders {
+		out = append(out, logicConsumer{Label: e.Name + " encoder", Rail: e.Rail, Current: e.SupplyCurrent})
+	}
+	for _, ls := range spec.Shifters {
+		out = append(out, logicConsumer{Label: ls.Name, Rail: ls.BRail, Current: ls.SupplyCurrent})
+	}
+	return out
+}
+
+// supplyLoad converts declared supply currents into continuous (typical, else quiescent)
+// and peak (peak, else continuous) demand.
+func supplyLoad(c model.SupplyCurrent) railLoad {
+	cont := c.TypicalA
+	if cont <= 0 {
+		cont = c.QuiescentA
+	}
+	peak := c.PeakA
+	if peak < cont {
+		peak = cont
+	}
+	return railLoad{ContinuousA: cont, PeakA: peak}
+}
+
+// scaleSupplyCurrent multiplies a per-device supply current by an instance count.
+func scaleSupplyCurrent(c model.SupplyCurrent, n int) model.SupplyCurrent {
+	k := float64(n)
+	return model.SupplyCurrent{QuiescentA: c.QuiescentA * k, TypicalA: c.TypicalA * k, PeakA: c.PeakA * k}
+}
+
+// directLoads collects consumer demand keyed by supply name (rail name, "battery",
+// or legacyLogicRail).
+func directLoads(spec model.RobotSpec) map[string]railLoad {
+	loads := dcMotorLoads(spec)
+	for _, st := range spec.Steppers {
+		sp, ok := motorSupply(spec, st.Driver.SupplyRail)
+		if !ok {
+			continue
+		}
+		loads[sp.Name] = loads[sp.Name].add(stepperSupplyLoad(st, sp.VoltageV))
+	}
+	for _, sv := range spec.Servos {
+		sp, ok := motorSupply(spec, sv.SupplyRail)
+		if !ok {
+			continue
+		}
+		n := float64(sv.Count)
+		loads[sp.Name] = loads[sp.Name].add(railLoad{ContinuousA: sv.IdleCurrentA * n, PeakA: sv.StallCurrentA * n})
+	}
+	for _, c := range logicConsumers(spec) {
+		loads[c.Rail] = loads[c.Rail].add(supplyLoad(c.Current))
+	}
+	return loads
+}
+
+// dcMotorLoads is the nominal and stall current of every DC motor instance keyed by
+// the supply of the driver it is wired to.
+func dcMotorLoads(spec model.RobotSpec) map[string]railLoad {
+	loads := make(map[string]railLoad)
+	entries := driverEntries(spec)
+	for _, iw := range wireInstances(spec, entries) {
+		supply := batterySource
+		if iw.Driver >= 0 {
+			supply = driverSupplyName(entries[iw.Driver].Driver)
+		}
+		loads[supply] = loads[supply].add(railLoad{ContinuousA: iw.Motor.NominalCurrentA, PeakA: instanceStallA(spec, entries, iw)})
+	}
+	return loads
+}
+
+// instanceStallA is the stall current of one motor instance at its driver's terminals.
+func instanceStallA(spec model.RobotSpec, entries []driverEntry, iw instanceWiring) float64 {
+	if iw.Driver < 0 {
+		return iw.Motor.StallCurrentA
+	}
+	e := entries[iw.Driver]
+	supply, ok := motorSupply(spec, e.Driver.SupplyRail)
+	if !ok {
+		return iw.Motor.StallCurrentA
+	}
+	g := channelLoad{Channels: iw.Channels, Motors: []motorInstance{iw.motorInstance}}
+	if len(g.Channels) == 0 {
+		g.Channels = []int{1}
+	}
+	return g.stallA(e.Driver, supply.VoltageV)
+}
+
+// railLoads returns the output demand of every named rail plus the battery, pushing
+// regulator input current up the tree so parents see their children. Cycles are
+// ignored here and reported by rulePowerTree.
+func railLoads(spec model.RobotSpec) map[string]railLoad {
+	return railLoadsFrom(spec, directLoads(spec))
+}
+
+// railLoadsFrom is railLoads for an arbitrary set of direct loads.
+func railLoadsFrom(spec model.RobotSpec, direct map[string]railLoad) map[string]railLoad {
+	totals := make(map[string]railLoad)
+	visiting := make(map[string]bool)
+
+	var total func(name string) railLoad
+	total = func(name string) railLoad {
+		if l, ok := totals[name]; ok {
+			return l
+		}
+		if visiting[name] {
+			return railLoad{}
+		}
+		visiting[name] = true
+		load := direct[name]
+		for _, child := range spec.Power.Rails {
+			if child.Name == "" || railSource(child) != name {
+				continue
+			}
+			out := total(child.Name)
+			idx, _ := findRail(spec, child.Name)
+			vin := railInputV(spec, child)
+			vout := railOutputV(spec, idx)
+			load = load.add(railLoad{
+				ContinuousA: regulatorInputA(child, vin, vout, out.ContinuousA),
+				PeakA:       regulatorInputA(child, vin, vout, out.PeakA),
+			})
+		}
+		visiting[name] = false
+		totals[name] = load
+		return load
+	}
+
+	totals[legacyLogicRail] = direct[legacyLogicRail]
+	total(batterySource)
+	for _, rail := range spec.Power.Rails {
+		if rail.Name != "" {
+			total(rail.Name)
+		}
+	}
+	return totals
+}
+
+func rulePowerTree(spec model.RobotSpec, locs map[string]Location) []Finding {
+	if len(spec.Power.Rails) == 0 {
+		return nil
+	}
+
+	var out []Finding
+	seen := make(map[string]bool)
+	for i, rail := range spec.Power.Rails {
+		base := fmt.Sprintf("power.rails[%d]", i)
+		if rail.Name == "" {
+			out = append(out, withLocation(locs, base, Finding{
+				Severity: SevError,
+				Code:     "RAIL_NAME_MISSING",
+				Message:  fmt.Sprintf("%s.name must be set", base),
+			}))
+			continue
+		}
+		if rail.Name == batterySource || seen[rail.Name] {
+			out = append(out, withLocation(locs, base+".name", Finding{
+				Severity: SevError,
+				Code:     "RAIL_NAME_DUPLICATE",
+				Message:  fmt.Sprintf("rail name %q is already used", rail.Name),
+			}))
+			continue
+		}
+		seen[rail.Name] = true
+	}
+
+	for i, rail := range spec.Power.Rails {
+		if rail.Name == "" {
+			continue
+		}
+		base := fmt.Sprintf("power.rails[%d]", i)
+		src := railSource(rail)
+		if _, ok := namedSupply(spec, src); !ok {
+			out = append(out, withLocation(locs, base+".source", Finding{
+				Severity: SevError,
+				Code:     "RAIL_SOURCE_UNKNOWN",
+				Message:  fmt.Sprintf("rail %s source %q is neither battery nor a declared rail", rail.Name, src),
+			}))
+			continue
+		}
+		if railInCycle(spec, i) {
+			out = append(out, withLocation(locs, base+".source", Finding{
+				Severity: SevError,
+				Code:     "RAIL_SOURCE_CYCLE",
+				Message:  fmt.Sprintf("rail %s is part of a source cycle and never reaches the battery", rail.Name),
+			}))
+			continue
+		}
+		out = append(out, checkRegulator(spec, locs, i)...)
+	}
+	return out
+}
+
+// railInCycle reports whether following sources from the rail loops back without
+// reaching the battery.
+func railInCycle(spec model.RobotSpec, idx int) bool {
+	seen := make(map[int]bool)
+	for !seen[idx] {
+		seen[idx] = true
+		src := railSource(spec.Power.Rails[idx])
+		if src == batterySource {
+			return false
+		}
+		next, ok := findRail(spec, src)
+		if !ok {
+			return false
+		}
+		idx = next
+	}
+	return true
+}
+
+func checkRegulator(spec model.RobotSpec, locs map[string]Location, idx int) []Finding {
+	rail := spec.Power.Rails[idx]
+	base := fmt.Sprintf("power.rails[%d]", idx)
+	reg := railRegulator(rail)
+
+	var out []Finding
+	if rail.VoltageV < 0 || (rail.VoltageV == 0 && reg != "none") {
+		return []Finding{withLocation(locs, base+".voltage_v", Finding{
+			Severity: SevError,
+			Code:     "RAIL_V_INVALID",
+			Message:  base + ".voltage_v must be > 0",
+		})}
+	}
+	if rail.Efficiency < 0 || rail.Efficiency > 1 {
+		out = append(out, withLocation(locs, base+".efficiency", Finding{
+			Severity: SevError,
+			Code:     "RAIL_EFFICIENCY_INVALID",
+			Message:  fmt.Sprintf("rail %s efficiency %.2f must be within (0, 1]", rail.Name, rail.Efficiency),
+		}))
+	}
+
+	vin := railInputV(spec, rail)
+	vout := railOutputV(spec, idx)
+	if vin <= 0 || vout <= 0 {
+		return out
+	}
+	switch reg {
+	case "ldo":
+		if vin < vout+rail.DropoutV {
+			out = append(out, withLocation(locs, base+".dropout_v", Finding{
+				Severity: SevError,
+				Code:     "RAIL_DROPOUT",
+				Message: fmt.Sprintf(
+					"rail %s LDO needs %.2fV in (%.2fV out + %.2fV dropout) but source provides %.2fV",
+					rail.Name, vout+rail.DropoutV, vout, rail.DropoutV, vin,
+				),
+			}))
+		}
+	case "buck":
+		if vin <= vout {
+			out = append(out, withLocation(locs, base+".voltage_v", Finding{
+				Severity: SevError,
+				Code:     "RAIL_REGULATOR_INPUT",
+				Message:  fmt.Sprintf("rail %s buck cannot produce %.2fV from %.2fV input", rail.Name, vout, vin),
+			}))
+		}
+	case "boost":
+		if vin >= vout {
+			out = append(out, withLocation(locs, base+".voltage_v", Finding{
+				Severity: SevError,
+				Code:     "RAIL_REGULATOR_INPUT",
+				Message:  fmt.Sprintf("rail %s boost cannot produce %.2fV from %.2fV input", rail.Name, vout, vin),
+			}))
+		}
+	case "buck_boost":
+	case "none":
+		if math.Abs(vin-vout) > 0.05*vin {
+			out = append(out, withLocation(locs, base+".voltage_v", Finding{
+				Severity: SevWarn,
+				Code:     "RAIL_PASSTHROUGH_MISMATCH",
+				Message:  fmt.Sprintf("rail %s has no regulator but declares %.2fV while its source provides %.2fV", rail.Name, vout, vin),
+			}))
+		}
+	default:
+		out = append(out, withLocation(locs, base+".regulator", Finding{
+			Severity: SevError,
+			Code:     "RAIL_REGULATOR_UNKNOWN",
+			Message:  fmt.Sprintf("rail %s regulator %q is not one of buck, boost, buck_boost, ldo, none", rail.Name, rail.Regulator),
+		}))
+	}
+	return out
+}
+
+// railRef is a consumer field that names a rail.
+type railRef struct {
+	path string
+	name string
+}
+
+func ruleRailReferences(spec model.RobotSpec, locs map[string]Location) []Finding {
+	refs := []railRef{{"mcu.rail", spec.MCU.Rail}}
+	for _, e := range driverEntries(spec) {
+		refs = append(refs,
+			railRef{e.Path + ".logic_rail", e.Driver.LogicRail},
+			railRef{e.Path + ".supply_rail", e.Driver.SupplyRail},
+		)
+	}
+	for i, st := range spec.Steppers {
+		refs = append(refs,
+			railRef{fmt.Sprintf("steppers[%d].driver.logic_rail", i), st.Driver.LogicRail},
+			railRef{fmt.Sprintf("steppers[%d].driver.supply_rail", i), st.Driver.SupplyRail},
+		)
+	}
+	for i, sv := range spec.Servos {
+		refs = append(refs, railRef{fmt.Sprintf("servos[%d].supply_rail", i), sv.SupplyRail})
+	}
+	for i, c := range spec.PWM {
+		refs = append(refs, railRef{fmt.Sprintf("pwm_controllers[%d].rail", i), c.Rail})
+	}
+	for i, b := range spec.BLDC {
+		if b.ESC.BEC.Feeds != becLogicRail {
+			refs = append(refs, railRef{fmt.Sprintf("bldc[%d].esc.bec.feeds", i), b.ESC.BEC.Feeds})
+		}
+	}
+	for i, bus := range spec.I2CBuses {
+		refs = append(refs, railRef{fmt.Sprintf("i2c_buses[%d].rail", i), bus.Rail})
+		for _, d := range i2cBusDevices(bus, i) {
+			refs = append(refs, railRef{d.Path + ".rail", d.Device.Rail})
+		}
+	}
+	for i, bus := range spec.SPIBuses {
+		refs = append(refs, railRef{fmt.Sprintf("spi_buses[%d].rail", i), bus.Rail})
+		for j, d := range bus.Devices {
+			refs = append(refs, railRef{fmt.Sprintf("spi_buses[%d].devices[%d].rail", i, j), d.Rail})
+		}
+	}
+	for i, u := range spec.UARTs {
+		refs = append(refs, railRef{fmt.Sprintf("uarts[%d].rail", i), u.Rail})
+	}
+	for i, bus := range spec.CANBuses {
+		for j, n := range bus.Nodes {
+			refs = append(refs, railRef{fmt.Sprintf("can_buses[%d].nodes[%d].rail", i, j), n.Rail})
+		}
+	}
+	for i, e := range spec.Encoders {
+		refs = append(refs, railRef{fmt.Sprintf("encoders[%d].rail", i), e.Rail})
+	}
+	for i, ls := range spec.Shifters {
+		refs = append(refs,
+			railRef{fmt.Sprintf("level_shifters[%d].a_rail", i), ls.ARail},
+			railRef{fmt.Sprintf("level_shifters[%d].b_rail", i), ls.BRail},
+		)
+	}
+
+	var out []Finding
+	for _, ref := range refs {
+		if ref.name == "" {
+			continue
+		}
+		if _, ok := namedSupply(spec, ref.name); ok {
+			continue
+		}
+		out = append(out, withLocation(locs, ref.path, Finding{
+			Severity: SevError,
+			Code:     "RAIL_UNKNOWN",
+			Message:  fmt.Sprintf("%s references unknown rail %q", ref.path, ref.name),
+		}))
+	}
+	return out
+}
+
+func ruleMCUSupply(spec model.RobotSpec, locs map[string]Location) []Finding {
+	if spec.MCU.Rail == "" {
+		return nil
+	}
+	rail, ok := logicSupply(spec, spec.MCU.Rail)
+	if !ok || rail.VoltageV <= 0 {
+		return nil
+	}
+
+	minV, maxV := spec.MCU.SupplyMinV, spec.MCU.SupplyMaxV
+	if minV > 0 && maxV > 0 {
+		if rail.VoltageV < minV || rail.VoltageV > maxV {
+			return []Finding{withLocation(locs, "mcu.rail", Finding{
+				Severity: SevError,
+				Code:     "MCU_SUPPLY_RANGE",
+				Message:  fmt.Sprintf("%s %.2fV outside MCU supply range [%.2f, %.2f]V", rail.Label(), rail.VoltageV, minV, maxV),
+			})}
+		}
+		return nil
+	}
+	if spec.MCU.LogicVoltageV > 0 && math.Abs(spec.MCU.LogicVoltageV-rail.VoltageV) > 0.25 {
+		return []Finding{withLocation(locs, "mcu.rail", Finding{
+			Severity: SevWarn,
+			Code:     "MCU_RAIL_MISMATCH",
+			Message: fmt.Sprintf(
+				"MCU logic %.2fV is powered from %s %.2fV and no supply range is declared, check the board regulator",
+				spec.MCU.LogicVoltageV, rail.Label(), rail.VoltageV,
+			),
+		})}
+	}
+	return nil
+}
+
+func ruleRailLoads(spec model.RobotSpec, locs map[string]Location) []Finding {
+	if len(spec.Power.Rails) == 0 {
+		return nil
+	}
+
+	loads := railLoads(spec)
+	var out []Finding
+	for i, rail := range spec.Power.Rails {
+		if rail.Name == "" || rail.MaxCurrentA <= 0 {
+			continue
+		}
+		load := loads[rail.Name]
+		path := fmt.Sprintf("power.rails[%d].max_current_a", i)
+		switch {
+		case load.ContinuousA > rail.MaxCurrentA:
+			out = append(out, withLocation(locs, path, Finding{
+				Severity: SevError,
+				Code:     "RAIL_OVERLOAD",
+				Message:  fmt.Sprintf("rail %s continuous load %.2fA exceeds max %.2fA", rail.Name, load.ContinuousA, rail.MaxCurrentA),
+			}))
+		case load.PeakA > rail.MaxCurrentA:
+			out = append(out, withLocation(locs, path, Finding{
+				Severity: SevWarn,
+				Code:     "RAIL_PEAK_OVERLOAD",
+				Message:  fmt.Sprintf("rail %s peak load %.2fA exceeds max %.2fA, expect brownout under stall", rail.Name, load.PeakA, rail.MaxCurrentA),
+			}))
+		case load.ContinuousA >= 0.8*rail.MaxCurrentA:
+			out = append(out, withLocation(locs, path, Finding{
+				Severity: SevWarn,
+				Code:     "RAIL_MARGIN_LOW",
+				Message:  fmt.Sprintf("rail %s continuous load %.2fA is close to max %.2fA", rail.Name, load.ContinuousA, rail.MaxCurrentA),
+			}))
+		}
+	}
+	return out
+}
@@ -0,0 +1,238 @@
+package validate
+
+import (
+	"math"
+	"testing"
+
+	"github.com/badimirzai/robotics-verifier-cli/internal/model"
+)
+
+func treeSpec() model.RobotSpec {
+	spec := baseSpec()
+	spec.Power.Rails = []model.PowerRail{
+		{Name: "5v", Source: "battery", Regulator: "buck", VoltageV: 5, Efficiency: 0.9, MaxCurrentA: 3},
+		{Name: "3v3", Source: "5v", Regulator: "ldo", VoltageV: 3.3, DropoutV: 0.3, MaxCurrentA: 0.5},
+	}
+	spec.MCU.LogicVoltageV = 3.3
+	spec.MCU.Rail = "3v3"
+	spec.Driver.LogicRail = "5v"
+	return spec
+}
+
+func TestRulePowerTree(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*model.RobotSpec)
+		want   []string
+		not    []string
+	}{
+		{
+			name:   "valid_chain",
+			mutate: func(s *model.RobotSpec) {},
+			not: []string{
+				"RAIL_NAME_MISSING", "RAIL_NAME_DUPLICATE", "RAIL_SOURCE_UNKNOWN", "RAIL_SOURCE_CYCLE",
+				"RAIL_DROPOUT", "RAIL_REGULATOR_INPUT", "RAIL_REGULATOR_UNKNOWN", "RAIL_UNKNOWN",
+			},
+		},
+		{
+			name: "duplicate_name",
+			mutate: func(s *model.RobotSpec) {
+				s.Power.Rails[1].Name = "5v"
+			},
+			want: []string{"RAIL_NAME_DUPLICATE"},
+		},
+		{
+			name: "unknown_source",
+			mutate: func(s *model.RobotSpec) {
+				s.Power.Rails[1].Source = "12v"
+			},
+			want: []string{"RAIL_SOURCE_UNKNOWN"},
+		},
+		{
+			name: "source_cycle",
+			mutate: func(s *model.RobotSpec) {
+				s.Power.Rails[0].Source = "3v3"
+			},
+			want: []string{"RAIL_SOURCE_CYCLE"},
+		},
+		{
+			name: "ldo_dropout",
+			mutate: func(s *model.RobotSpec) {
+				s.Power.Rails[0].VoltageV = 3.5
+			},
+			want: []string{"RAIL_DROPOUT"},
+		},
+		{
+			name: "buck_input_too_low",
+			mutate: func(s *model.RobotSpec) {
+				s.Power.Battery.VoltageV = 4.5
+			},
+			want: []string{"RAIL_REGULATOR_INPUT"},
+		},
+		{
+			name: "unknown_regulator",
+			mutate: func(s *model.RobotSpec) {
+				s.Power.Rails[0].Regulator = "charge_pump"
+			},
+			want: []string{"RAIL_REGULATOR_UNKNOWN"},
+		},
+		{
+			name: "invalid_efficiency",
+			mutate: func(s *model.RobotSpec) {
+				s.Power.Rails[0].Efficiency = 1.5
+			},
+			want: []string{"RAIL_EFFICIENCY_INVALID"},
+		},
+		{
+			name: "consumer_unknown_rail",
+			mutate: func(s *model.RobotSpec) {
+				s.MCU.Rail = "1v8"
+			},
+			want: []string{"RAIL_UNKNOWN"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := treeSpec()
+			tt.mutate(&spec)
+			codes := reportCodes(RunAll(spec, nil))
+			for _, c := range tt.want {
+				requireHasCode(t, codes, c)
+			}
+			for _, c := range tt.not {
+				requireNoCode(t, codes, c)
+			}
+		})
+	}
+}
+
+func TestRuleRailConsumers(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*model.RobotSpec)
+		want   []string
+		not    []string
+	}{
+		{
+			name:   "consumers_match_rails",
+			mutate: func(s *model.RobotSpec) {},
+			not:    []string{"LOGIC_V_DRIVER_MISMATCH", "MCU_SUPPLY_RANGE", "MCU_RAIL_MISMATCH", "DRV_SUPPLY_RANGE"},
+		},
+		{
+			name: "driver_logic_on_wrong_rail",
+			mutate: func(s *model.RobotSpec) {
+				s.Driver.LogicRail = "3v3"
+			},
+			want: []string{"LOGIC_V_DRIVER_MISMATCH"},
+		},
+		{
+			name: "mcu_supply_out_of_range",
+			mutate: func(s *model.RobotSpec) {
+				s.MCU.Rail = "5v"
+				s.MCU.SupplyMinV = 3.0
+				s.MCU.SupplyMaxV = 3.6
+			},
+			want: []string{"MCU_SUPPLY_RANGE"},
+		},
+		{
+			name: "mcu_rail_without_supply_range",
+			mutate: func(s *model.RobotSpec) {
+				s.MCU.Rail = "5v"
+			},
+			want: []string{"MCU_RAIL_MISMATCH"},
+			not:  []string{"MCU_SUPPLY_RANGE"},
+		},
+		{
+			name: "driver_supply_from_regulated_rail",
+			mutate: func(s *model.RobotSpec) {
+				s.Driver.SupplyRail = "5v"
+			},
+			want: []string{"DRV_SUPPLY_RANGE"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := treeSpec()
+			tt.mutate(&spec)
+			codes := reportCodes(RunAll(spec, nil))
+			for _, c := range tt.want {
+				requireHasCode(t, codes, c)
+			}
+			for _, c := range tt.not {
+				requireNoCode(t, codes, c)
+			}
+		})
+	}
+}
+
+func TestRuleRailLoads(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*model.RobotSpec)
+		want   []string
+		not    []string
+	}{
+		{
+			name:   "motors_on_battery",
+			mutate: func(s *model.RobotSpec) {},
+			not:    []string{"RAIL_OVERLOAD", "RAIL_PEAK_OVERLOAD", "RAIL_MARGIN_LOW"},
+		},
+		{
+			name: "motors_overload_rail",
+			mutate: func(s *model.RobotSpec) {
+				s.Power.Rails[0].Regulator = "none"
+				s.Power.Rails[0].VoltageV = 0
+				s.Power.Rails[0].MaxCurrentA = 1.5
+				s.Driver.SupplyRail = "5v"
+			},
+			want: []string{"RAIL_OVERLOAD"},
+		},
+		{
+			name: "motors_stall_over_rail",
+			mutate: func(s *model.RobotSpec) {
+				s.Power.Rails[0].Regulator = "none"
+				s.Power.Rails[0].VoltageV = 0
+				s.Power.Rails[0].MaxCurrentA = 4
+				s.Driver.SupplyRail = "5v"
+			},
+			want: []string{"RAIL_PEAK_OVERLOAD"},
+			not:  []string{"RAIL_OVERLOAD"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := treeSpec()
+			tt.mutate(&spec)
+			codes := reportCodes(RunAll(spec, nil))
+			for _, c := range tt.want {
+				requireHasCode(t, codes, c)
+			}
+			for _, c := range tt.not {
+				requireNoCode(t, codes, c)
+			}
+		})
+	}
+}
+
+func TestRailLoadsPropagateThroughRegulators(t *testing.T) {
+	spec := treeSpec()
+	spec.Motors = nil
+	spec.Power.Rails = append(spec.Power.Rails, model.PowerRail{Name: "servo", Source: "3v3", Regulator: "none"})
+	spec.Driver.SupplyRail = "servo"
+	spec.Motors = []model.Motor{{Name: "M", Count: 1, NominalCurrentA: 0.3, StallCurrentA: 0.6}}
+
+	loads := railLoads(spec)
+	if got := loads["3v3"].ContinuousA; math.Abs(got-0.3) > 1e-9 {
+		t.Fatalf("expected LDO output 0.3A, got %.4f", got)
+	}
+	if got := loads["5v"].ContinuousA; math.Abs(got-0.3) > 1e-9 {
+		t.Fatalf("expected LDO input 0.3A on 5v, got %.4f", got)
+	}
+	want := 5 * 0.3 / (12 * 0.9)
+	if got := loads[batterySource].ContinuousA; math.Abs(got-want) > 1e-9 {
+		t.Fatalf("expected buck input %.4fA on battery, got %.4f", want, got)
+	}
+}
@@ -0,0 +1,273 @@
+package validate
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/badimirzai/robotics-verifier-cli/internal/model"
+)
+
+// defaultRegenFraction is the bus rise assumed, as a share of the supply voltage, when
+// the motors declare no inertia: a brushed motor braking from full speed on a source
+// that cannot sink current.
+const defaultRegenFraction = 0.25
+
+// defaultBusCapUF is the local decoupling a driver board has when no bulk capacitor
+// is declared.
+const defaultBusCapUF = 10
+
+// regenMarginFraction is the headroom below motor_supply_max_v an unprotected bus
+// should keep at its estimated peak.
+const regenMarginFraction = 0.2
+
+// batteryKindPSU marks a bench supply, which cannot sink regenerated current.
+const batteryKindPSU = "psu"
+
+// regenEstimate is the worst case motor supply voltage while a driver's motors brake.
+type regenEstimate struct {
+	SupplyV float64
+	PeakV   float64
+	RegenA  float64 // braking current, the stall current of every motor on the driver
+	EnergyJ float64 // kinetic energy returned, 0 when not computed from inertia
+	Basis   string
+
+	IntoPack     bool // the battery takes the current back through its resistance
+	FromFraction bool // no inertia data, the bus rises by regen_fraction
+	NoResistance bool // the battery sinks but internal_resistance_ohm is unknown
+}
+
+// meta reports the estimate with peakV, the peak the finding's message states.
+func (r regenEstimate) meta(peakV float64) map[string]any {
+	m := map[string]any{
+		"supply_v":    roundMilli(r.SupplyV),
+		"peak_v":      roundMilli(peakV),
+		"overshoot_v": roundMilli(peakV - r.SupplyV),
+		"regen_a":     roundMilli(r.RegenA),
+	}
+	if r.EnergyJ > 0 {
+		m["energy_j"] = roundMilli(r.EnergyJ)
+	}
+	return m
+}
+
+func regenFraction(d model.MotorDriver) float64 {
+	if d.RegenFraction > 0 {
+		return d.RegenFraction
+	}
+	return defaultRegenFraction
+}
+
+// motorKineticJ is the energy stored in a spinning motor and its load at no load rpm,
+// with the load inertia reflected through the gearbox. ok is false without the data.
+func motorKineticJ(m model.Motor) (float64, bool) {
+	if m.RotorInertiaKgM2 <= 0 || m.NoLoadRPM <= 0 {
+		return 0, false
+	}
+	gear := m.GearRatio
+	if gear <= 0 {
+		gear = 1
+	}
+	omega := m.NoLoadRPM * gear * 2 * math.Pi / 60
+	j := m.RotorInertiaKgM2 + m.LoadInertiaKgM2/(gear*gear)
+	return 0.5 * j * omega * omega, true
+}
+
+// regenSupplyV is the highest voltage a driver's supply sits at before braking: a
+// known battery chemistry at full charge, else the nominal supply.
+func regenSupplyV(spec model.RobotSpec, supply supplyPoint) float64 {
+	b := spec.Power.Battery
+	if supply.Name != batterySource {
+		return supply.VoltageV
+	}
+	if chem, ok := chemistryFor(b.Chemistry); ok && batteryCells(b) > 0 {
+		return float64(batteryCells(b)) * chem.MaxV
+	}
+	return supply.VoltageV
+}
+
+// estimateRegen returns the bus peak while every motor on a driver brakes from full
+// speed. A battery takes the current back, rising by its stall current times its
+// internal resistance; without that resistance the estimate is marked NoResistance. A
+// bench supply or a regulated rail cannot sink, so the kinetic energy charges the bulk
+// capacitance, or the bus rises by regen_fraction when the motors lack inertia data.
+func estimateRegen(spec model.RobotSpec, idx int, e driverEntry) (regenEstimate, bool) {
+	supply, ok := motorSupply(spec, e.Driver.SupplyRail)
+	if !ok || supply.VoltageV <= 0 {
+		return regenEstimate{}, false
+	}
+	entries := driverEntries(spec)
+	var stallA, energyJ float64
+	motors, withInertia := 0, 0
+	for _, iw := range wireInstances(spec, entries) {
+		if iw.Driver != idx {
+			continue
+		}
+		motors++
+		stallA += instanceStallA(spec, entries, iw)
+		if j, ok := motorKineticJ(iw.Motor); ok {
+			energyJ += j
+			withInertia++
+		}
+	}
+	if motors == 0 {
+		return regenEstimate{}, false
+	}
+
+	r := regenEstimate{SupplyV: regenSupplyV(spec, supply), RegenA: stallA}
+	b := spec.Power.Battery
+	sinks := supply.Name == batterySource && !strings.EqualFold(strings.TrimSpace(b.Kind), batteryKindPSU)
+	if sinks {
+		if stallA <= 0 {
+			return regenEstimate{}, false
+		}
+		if b.InternalResistanceOhm <= 0 {
+			r.NoResistance = true
+			return r, true
+		}
+		r.IntoPack = true
+		r.PeakV = r.SupplyV + stallA*b.InternalResistanceOhm
+		r.Basis = fmt.Sprintf("%.2fA back into the pack x %.3fΩ", stallA, b.InternalResistanceOhm)
+		return r, true
+	}
+	if withInertia == motors {
+		capUF := e.Driver.BulkCapUF
+		if capUF <= 0 {
+			capUF = defaultBusCapUF
+		}
+		c := capUF * 1e-6
+		r.EnergyJ = energyJ
+		r.PeakV = math.Sqrt(r.SupplyV*r.SupplyV + 2*energyJ/c)
+		r.Basis = fmt.Sprintf("%.3fJ of motor kinetic energy into %.0fµF", energyJ, capUF)
+	} else {
+		f := regenFraction(e.Driver)
+		r.FromFraction = true
+		r.PeakV = r.SupplyV * (1 + f)
+		r.Basis = fmt.Sprintf("regen fraction %.2f", f)
+	}
+	source := supply.Label()
+	if supply.Name == batterySource {
+		source = "bench supply"
+	}
+	r.Basis += ", " + source + " cannot sink current"
+	return r, true
+}
+
+// regenAdvice is the fix suggested for a bus that rises above motor_supply_max_v. It
+// leaves out protection the driver already declares and points at missing inertia
+// data when a declared bulk capacitor is not part of the estimate.
+func regenAdvice(drv model.MotorDriver, r regenEstimate) string {
+	if r.FromFraction && drv.BulkCapUF > 0 {
+		return fmt.Sprintf(
+			"The %.0fµF bulk_cap_uf only counts once every motor sets rotor_inertia_kgm2 and no_load_rpm; add that data, a TVS or a brake resistor.",
+			drv.BulkCapUF,
+		)
+	}
+	var add []string
+	switch {
+	case r.IntoPack:
+	case drv.BulkCapUF > 0:
+		add = append(add, "more bulk capacitance")
+	default:
+		add = append(add, "bulk capacitance")
+	}
+	if drv.TVSClampV > 0 {
+		add = append(add, "a TVS clamping lower")
+	} else {
+		add = append(add, "a TVS")
+	}
+	add = append(add, "a brake resistor")
+	return "Add " + strings.Join(add[:len(add)-1], ", ") + " or " + add[len(add)-1] + "."
+}
+
+func ruleRegen(spec model.RobotSpec, locs map[string]Location) []Finding {
+	var out []Finding
+	for idx, e := range driverEntries(spec) {
+		drv := e.Driver
+		maxV := drv.MotorSupplyMaxV
+		if maxV <= 0 {
+			continue
+		}
+		r, ok := estimateRegen(spec, idx, e)
+		if !ok {
+			continue
+		}
+		if r.NoResistance {
+			out = append(out, withLocation(locs, "power.battery", Finding{
+				Severity: SevInfo,
+				Code:     "REGEN_UNKNOWN",
+				Message: fmt.Sprintf(
+					"%s bus rise when the motors brake is not estimated: the battery takes the %.2fA back, but power.battery.internal_resistance_ohm is not set",
+					e.Label(), r.RegenA,
+				),
+			}))
+			continue
+		}
+		peakV := r.PeakV
+		clamp := ""
+		if drv.TVSClampV > 0 && drv.TVSClampV < peakV {
+			peakV = drv.TVSClampV
+			clamp = fmt.Sprintf(", clamped by a %.2fV TVS", drv.TVSClampV)
+		}
+		brakeA := 0.0
+		if drv.BrakeResistorOhm > 0 {
+			brakeA = maxV / drv.BrakeResistorOhm
+		}
+		margin := (1 - regenMarginFraction) * maxV
+		protected := drv.BulkCapUF > 0 || drv.TVSClampV > 0 || drv.BrakeResistorOhm > 0
+
+		switch {
+		case brakeA > 0 && brakeA >= r.RegenA && r.PeakV > margin:
+			out = append(out, withLocation(locs, e.Path+".brake_resistor_ohm", Finding{
+				Severity: SevInfo,
+				Code:     "REGEN_CLAMPED",
+				Message: fmt.Sprintf(
+					"%s bus would rise to %.2fV when braking (%s); the %.2fΩ brake resistor sinks %.2fA at %.2fV, covering the %.2fA regen current",
+					e.Label(), r.PeakV, r.Basis, drv.BrakeResistorOhm, brakeA, maxV, r.RegenA,
+				),
+				Meta: r.meta(r.PeakV),
+			}))
+		case brakeA > 0 && peakV > maxV:
+			out = append(out, withLocation(locs, e.Path+".brake_resistor_ohm", Finding{
+				Severity: SevWarn,
+				Code:     "REGEN_BRAKE_UNDERSIZED",
+				Message: fmt.Sprintf(
+					"%s bus rises to %.2fV when the motors brake (%s%s), above motor_supply_max_v %.2fV; the %.2fΩ brake resistor sinks only %.2fA there, below the %.2fA regen current. Use a lower resistance.",
+					e.Label(), peakV, r.Basis, clamp, maxV, drv.BrakeResistorOhm, brakeA, r.RegenA,
+				),
+				Meta: r.meta(peakV),
+			}))
+		case peakV > maxV:
+			out = append(out, withLocation(locs, e.Path+".motor_supply_max_v", Finding{
+				Severity: SevWarn,
+				Code:     "REGEN_OVERVOLTAGE",
+				Message: fmt.Sprintf(
+					"%s bus rises to %.2fV when the motors brake (%s%s), above motor_supply_max_v %.2fV. %s",
+					e.Label(), peakV, r.Basis, clamp, maxV, regenAdvice(drv, r),
+				),
+				Meta: r.meta(peakV),
+			}))
+		case !protected && peakV > margin:
+			out = append(out, withLocation(locs, e.Path+".motor_supply_max_v", Finding{
+				Severity: SevWarn,
+				Code:     "REGEN_MARGIN_LOW",
+				Message: fmt.Sprintf(
+					"%s bus rises to %.2fV when the motors brake (%s), within %.0f%% of motor_supply_max_v %.2fV and no bulk capacitor, TVS or brake resistor is declared",
+					e.Label(), peakV, r.Basis, regenMarginFraction*100, maxV,
+				),
+				Meta: r.meta(peakV),
+			}))
+		default:
+			out = append(out, withLocation(locs, e.Path, Finding{
+				Severity: SevInfo,
+				Code:     "REGEN_OK",
+				Message: fmt.Sprintf(
+					"%s bus peaks at %.2fV when the motors brake (%s%s), below motor_supply_max_v %.2fV",
+					e.Label(), peakV, r.Basis, clamp, maxV,
+				),
+				Meta: r.meta(peakV),
+			}))
+		}
+	}
+	return out
+}
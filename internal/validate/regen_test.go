@@ -0,0 +1,258 @@
+package validate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/badimirzai/robotics-verifier-cli/internal/model"
+)
+
+// regenSpec runs the base robot from a 12V bench supply into a TB6612FNG style
+// driver rated to 13.5V.
+func regenSpec() model.RobotSpec {
+	spec := baseSpec()
+	spec.Power.Battery.Kind = "psu"
+	spec.Driver.MotorSupplyMaxV = 13.5
+	return spec
+}
+
+func TestRuleRegen(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*model.RobotSpec)
+		want   []string
+		not    []string
+	}{
+		{
+			name:   "bench_supply_default_fraction",
+			mutate: func(s *model.RobotSpec) {},
+			want:   []string{"REGEN_OVERVOLTAGE"},
+			not:    []string{"REGEN_OK"},
+		},
+		{
+			name: "l298_headroom",
+			mutate: func(s *model.RobotSpec) {
+				s.Driver.MotorSupplyMaxV = 46
+			},
+			want: []string{"REGEN_OK"},
+			not:  []string{"REGEN_OVERVOLTAGE", "REGEN_MARGIN_LOW"},
+		},
+		{
+			name: "small_margin_unprotected",
+			mutate: func(s *model.RobotSpec) {
+				s.Power.Battery.VoltageV = 9
+			},
+			want: []string{"REGEN_MARGIN_LOW"},
+		},
+		{
+			name: "small_margin_with_bulk_cap",
+			mutate: func(s *model.RobotSpec) {
+				s.Power.Battery.VoltageV = 9
+				s.Driver.BulkCapUF = 470
+			},
+			want: []string{"REGEN_OK"},
+			not:  []string{"REGEN_MARGIN_LOW"},
+		},
+		{
+			name: "bulk_cap_without_inertia_over_max",
+			mutate: func(s *model.RobotSpec) {
+				s.Driver.BulkCapUF = 2200
+			},
+			want: []string{"REGEN_OVERVOLTAGE"},
+		},
+		{
+			name: "configured_fraction",
+			mutate: func(s *model.RobotSpec) {
+				s.Driver.RegenFraction = 0.1
+			},
+			want: []string{"REGEN_MARGIN_LOW"},
+			not:  []string{"REGEN_OVERVOLTAGE"},
+		},
+		{
+			name: "tvs_clamps_below_margin",
+			mutate: func(s *model.RobotSpec) {
+				s.Driver.TVSClampV = 10.5
+			},
+			want: []string{"REGEN_OK"},
+			not:  []string{"REGEN_OVERVOLTAGE", "REGEN_MARGIN_LOW"},
+		},
+		{
+			name: "tvs_clamps_below_max",
+			mutate: func(s *model.RobotSpec) {
+				s.Driver.TVSClampV = 13
+			},
+			want: []string{"REGEN_OK"},
+			not:  []string{"REGEN_OVERVOLTAGE", "REGEN_MARGIN_LOW"},
+		},
+		{
+			name: "tvs_clamps_too_high",
+			mutate: func(s *model.RobotSpec) {
+				s.Driver.TVSClampV = 14.5
+			},
+			want: []string{"REGEN_OVERVOLTAGE"},
+		},
+		{
+			name: "brake_resistor",
+			mutate: func(s *model.RobotSpec) {
+				s.Driver.BrakeResistorOhm = 1
+			},
+			want: []string{"REGEN_CLAMPED"},
+			not:  []string{"REGEN_OVERVOLTAGE", "REGEN_BRAKE_UNDERSIZED"},
+		},
+		{
+			name: "brake_resistor_too_large",
+			mutate: func(s *model.RobotSpec) {
+				s.Driver.BrakeResistorOhm = 10
+			},
+			want: []string{"REGEN_BRAKE_UNDERSIZED"},
+			not:  []string{"REGEN_CLAMPED"},
+		},
+		{
+			name: "inertia_into_bulk_cap",
+			mutate: func(s *model.RobotSpec) {
+				s.Power.Battery.VoltageV = 9
+				s.Motors[0].RotorInertiaKgM2 = 1e-8
+				s.Motors[0].NoLoadRPM = 150
+				s.Motors[0].GearRatio = 100
+				s.Driver.BulkCapUF = 2200
+			},
+			want: []string{"REGEN_OK"},
+		},
+		{
+			name: "inertia_into_board_decoupling",
+			mutate: func(s *model.RobotSpec) {
+				s.Motors[0].RotorInertiaKgM2 = 1e-8
+				s.Motors[0].NoLoadRPM = 150
+				s.Motors[0].GearRatio = 100
+			},
+			want: []string{"REGEN_OVERVOLTAGE"},
+		},
+		{
+			name: "battery_without_resistance_not_estimated",
+			mutate: func(s *model.RobotSpec) {
+				s.Power.Battery.Kind = ""
+			},
+			want: []string{"REGEN_UNKNOWN"},
+			not:  []string{"REGEN_OVERVOLTAGE", "REGEN_MARGIN_LOW", "REGEN_OK"},
+		},
+		{
+			name: "small_battery_at_full_charge",
+			mutate: func(s *model.RobotSpec) {
+				s.Power.Battery.Kind = ""
+				s.Power.Battery.Chemistry = "LiPo"
+				s.Power.Battery.Cells = 2
+				s.Power.Battery.InternalResistanceOhm = 0.1
+			},
+			want: []string{"REGEN_OK"},
+		},
+		{
+			name: "small_battery_high_resistance",
+			mutate: func(s *model.RobotSpec) {
+				s.Power.Battery.Kind = ""
+				s.Power.Battery.Chemistry = "LiPo"
+				s.Power.Battery.Cells = 2
+				s.Power.Battery.InternalResistanceOhm = 0.6
+			},
+			want: []string{"REGEN_OVERVOLTAGE"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := regenSpec()
+			tt.mutate(&spec)
+			codes := reportCodes(RunAll(spec, nil))
+			for _, c := range tt.want {
+				requireHasCode(t, codes, c)
+			}
+			for _, c := range tt.not {
+				requireNoCode(t, codes, c)
+			}
+		})
+	}
+}
+
+func TestRuleRegenMeta(t *testing.T) {
+	spec := regenSpec()
+	for _, f := range RunAll(spec, nil).Findings {
+		if f.Code != "REGEN_OVERVOLTAGE" {
+			continue
+		}
+		if f.Severity != SevWarn {
+			t.Errorf("expected REGEN_OVERVOLTAGE to warn, got %s", f.Severity)
+		}
+		if f.Meta["supply_v"] != 12.0 || f.Meta["peak_v"] != 15.0 || f.Meta["overshoot_v"] != 3.0 {
+			t.Errorf("unexpected regen meta: %+v", f.Meta)
+		}
+		return
+	}
+	t.Fatalf("expected REGEN_OVERVOLTAGE")
+}
+
+func TestRuleRegenTVSMeta(t *testing.T) {
+	spec := regenSpec()
+	spec.Driver.TVSClampV = 13
+	for _, f := range RunAll(spec, nil).Findings {
+		if f.Code != "REGEN_OK" {
+			continue
+		}
+		if f.Meta["peak_v"] != 13.0 || f.Meta["overshoot_v"] != 1.0 {
+			t.Errorf("expected meta to report the clamped peak, got %+v", f.Meta)
+		}
+		return
+	}
+	t.Fatalf("expected REGEN_OK")
+}
+
+func TestRuleRegenAdvice(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*model.RobotSpec)
+		want   string
+		not    string
+	}{
+		{
+			name:   "nothing_declared",
+			mutate: func(s *model.RobotSpec) {},
+			want:   "Add bulk capacitance, a TVS or a brake resistor.",
+		},
+		{
+			name: "bulk_cap_without_inertia",
+			mutate: func(s *model.RobotSpec) {
+				s.Driver.BulkCapUF = 2200
+			},
+			want: "rotor_inertia_kgm2 and no_load_rpm",
+			not:  "Add bulk capacitance",
+		},
+		{
+			name: "bulk_cap_too_small",
+			mutate: func(s *model.RobotSpec) {
+				s.Motors[0].RotorInertiaKgM2 = 1e-8
+				s.Motors[0].NoLoadRPM = 150
+				s.Motors[0].GearRatio = 100
+				s.Driver.BulkCapUF = 47
+			},
+			want: "Add more bulk capacitance, a TVS or a brake resistor.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := regenSpec()
+			tt.mutate(&spec)
+			for _, f := range RunAll(spec, nil).Findings {
+				if f.Code != "REGEN_OVERVOLTAGE" {
+					continue
+				}
+				if !strings.Contains(f.Message, tt.want) {
+					t.Errorf("expected %q in %q", tt.want, f.Message)
+				}
+				if tt.not != "" && strings.Contains(f.Message, tt.not) {
+					t.Errorf("did not expect %q in %q", tt.not, f.Message)
+				}
+				return
+			}
+			t.Fatalf("expected REGEN_OVERVOLTAGE")
+		})
+	}
+}
@@ -23,6 +23,7 @@ type Finding struct {
 	Message  string
 	Path     string
 	Location *Location
+	Meta     map[string]any // machine readable values behind the message, optional
 }
 
 type Report struct {
@@ -46,15 +47,47 @@ func (r Report) HasErrors() bool {
 
 func RunAll(spec model.RobotSpec, locs map[string]Location) Report {
 	var r Report
+	r.Findings = append(r.Findings, ruleMotorDriverAssignment(spec, locs)...)
+	r.Findings = append(r.Findings, ruleWiring(spec, locs)...)
 	r.Findings = append(r.Findings, ruleDriverChannels(spec, locs)...)
 	r.Findings = append(r.Findings, ruleMotorSupplyVoltage(spec, locs)...)
+	r.Findings = append(r.Findings, ruleBatteryCorners(spec, locs)...)
+	r.Findings = append(r.Findings, ruleMotorVoltage(spec, locs)...)
+	r.Findings = append(r.Findings, ruleBridgeLoss(spec, locs)...)
 	r.Findings = append(r.Findings, ruleDriverCurrentHeadroom(spec, locs)...)
+	r.Findings = append(r.Findings, ruleDriverThermal(spec, locs)...)
 	r.Findings = append(r.Findings, ruleLogicVoltageCompat(spec, locs)...)
 	r.Findings = append(r.Findings, ruleRailCurrentBudget(spec, locs)...)
 	r.Findings = append(r.Findings, ruleLogicLevelMisMatch(spec, locs)...)
+	r.Findings = append(r.Findings, ruleLevelShifters(spec, locs)...)
+	r.Findings = append(r.Findings, ruleLogicThresholds(spec, locs)...)
 	r.Findings = append(r.Findings, ruleBatteryCRate(spec, locs)...)
+	r.Findings = append(r.Findings, ruleHarness(spec, locs)...)
 	r.Findings = append(r.Findings, ruleDriverStallOverload(spec, locs)...)
+	r.Findings = append(r.Findings, ruleRegen(spec, locs)...)
 	r.Findings = append(r.Findings, ruleI2CAddressConflict(spec, locs)...)
+	r.Findings = append(r.Findings, ruleI2CTopology(spec, locs)...)
+	r.Findings = append(r.Findings, ruleI2CElectrical(spec, locs)...)
+	r.Findings = append(r.Findings, ruleSPI(spec, locs)...)
+	r.Findings = append(r.Findings, ruleUART(spec, locs)...)
+	r.Findings = append(r.Findings, ruleCAN(spec, locs)...)
+	r.Findings = append(r.Findings, ruleEncoders(spec, locs)...)
+	r.Findings = append(r.Findings, rulePins(spec, locs)...)
+	r.Findings = append(r.Findings, ruleStepperSupply(spec, locs)...)
+	r.Findings = append(r.Findings, ruleStepperCurrent(spec, locs)...)
+	r.Findings = append(r.Findings, ruleStepperMicrostep(spec, locs)...)
+	r.Findings = append(r.Findings, ruleStepperLogic(spec, locs)...)
+	r.Findings = append(r.Findings, ruleBLDCCells(spec, locs)...)
+	r.Findings = append(r.Findings, ruleBLDCCurrent(spec, locs)...)
+	r.Findings = append(r.Findings, ruleBLDCBEC(spec, locs)...)
+	r.Findings = append(r.Findings, ruleServoSupply(spec, locs)...)
+	r.Findings = append(r.Findings, ruleServoStall(spec, locs)...)
+	r.Findings = append(r.Findings, ruleServoSignal(spec, locs)...)
+	r.Findings = append(r.Findings, rulePWMControllers(spec, locs)...)
+	r.Findings = append(r.Findings, rulePowerTree(spec, locs)...)
+	r.Findings = append(r.Findings, ruleRailReferences(spec, locs)...)
+	r.Findings = append(r.Findings, ruleMCUSupply(spec, locs)...)
+	r.Findings = append(r.Findings, ruleRailLoads(spec, locs)...)
 	return r
 }
 
@@ -89,28 +122,36 @@ func findLocation(locs map[string]Location, path string) (Location, bool) {
 }
 
 func ruleDriverChannels(spec model.RobotSpec, locs map[string]Location) []Finding {
-	totalMotors := 0
-	for _, m := range spec.Motors {
-		totalMotors += m.Count
+	var out []Finding
+	for _, alloc := range allocateDrivers(spec) {
+		out = append(out, driverChannelsFor(alloc, locs)...)
 	}
-	if spec.Driver.Channels <= 0 {
-		return []Finding{withLocation(locs, "motor_driver.channels", Finding{
+	return out
+}
+
+func driverChannelsFor(alloc driverAllocation, locs map[string]Location) []Finding {
+	drv := alloc.Entry.Driver
+	path := alloc.Entry.Path + ".channels"
+	totalMotors := alloc.instances()
+	required := alloc.channelsUsed()
+	if drv.Channels <= 0 {
+		return []Finding{withLocation(locs, path, Finding{
 			Severity: SevError,
 			Code:     "DRV_CHANNELS_INVALID",
-			Message:  "motor_driver.channels must be > 0",
+			Message:  path + " must be > 0",
 		})}
 	}
-	if totalMotors > spec.Driver.Channels {
-		return []Finding{withLocation(locs, "motor_driver.channels", Finding{
+	if required > drv.Channels {
+		return []Finding{withLocation(locs, path, Finding{
 			Severity: SevError,
 			Code:     "DRV_CHANNELS_INSUFFICIENT",
-			Message:  fmt.Sprintf("motors require %d channels but motor_driver.channels is %d", totalMotors, spec.Driver.Channels),
+			Message:  fmt.Sprintf("motors require %d channels but %s is %d", required, path, drv.Channels),
 		})}
 	}
-	return []Finding{withLocation(locs, "motor_driver.channels", Finding{
+	return []Finding{withLocation(locs, path, Finding{
 		Severity: SevInfo,
 		Code:     "DRV_CHANNELS_OK",
-		Message:  fmt.Sprintf("driver channels OK: %d motor(s) mapped to %d available channel(s)", totalMotors, spec.Driver.Channels),
+		Message:  fmt.Sprintf("%s channels OK: %d motor(s) mapped to %d available channel(s)", driverNoun(alloc.Entry), totalMotors, drv.Channels),
 	})}
 }
 
@@ -123,56 +164,95 @@ func ruleMotorSupplyVoltage(spec model.RobotSpec, locs map[string]Location) []Fi
 			Message:  "power.battery.voltage_v must be > 0",
 		})}
 	}
-	if batV == 0 || spec.Driver.MotorSupplyMinV == 0 || spec.Driver.MotorSupplyMaxV == 0 {
-		return nil
-	}
-	if batV < spec.Driver.MotorSupplyMinV || batV > spec.Driver.MotorSupplyMaxV {
-		return []Finding{withLocation(locs, "power.battery.voltage_v", Finding{
-			Severity: SevError,
-			Code:     "DRV_SUPPLY_RANGE",
-			Message: fmt.Sprintf(
-				"battery %.2fV outside motor_driver motor supply range [%.2f, %.2f]V",
-				batV,
-				spec.Driver.MotorSupplyMinV,
-				spec.Driver.MotorSupplyMaxV,
-			),
-		})}
+	var out []Finding
+	for _, e := range driverEntries(spec) {
+		if w, ok := driverSupplyWindow(spec, e); ok && w.outside(w.loadedV()) {
+			out = append(out, w.finding(locs, SevError, w.loadedV(), ""))
+		}
 	}
-	return nil
+	return out
 }
 
 func ruleDriverCurrentHeadroom(spec model.RobotSpec, locs map[string]Location) []Finding {
 	var out []Finding
-	for _, m := range spec.Motors {
-		if m.Count <= 0 {
-			continue
+	seen := make(map[string]bool)
+	for _, alloc := range allocateDrivers(spec) {
+		for _, f := range driverHeadroomFor(spec, alloc, locs) {
+			// Identical channels (e.g. count: 2 of one motor) produce one finding.
+			key := f.Code + f.Path + f.Message
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			out = append(out, f)
 		}
+	}
+	return out
+}
+
+func driverHeadroomFor(spec model.RobotSpec, alloc driverAllocation, locs map[string]Location) []Finding {
+	drv := alloc.Entry.Driver
+	continuousPerChA, thermal := effectiveContinuousPerChA(spec, alloc)
+	var out []Finding
+	supplyV := alloc.supplyV(spec)
+	for _, ch := range alloc.Channels {
+		stallA := ch.stallA(drv, supplyV)
+		nominalA := ch.nominalA()
+		peakA := ch.ratingA(drv.PeakPerChA, drv)
+		continuousA := ch.ratingA(continuousPerChA, drv)
 		// Worst case per channel: stall current. If you want to be conservative, require peak >= stall.
-		if spec.Driver.PeakPerChA > 0 && m.StallCurrentA > 0 && spec.Driver.PeakPerChA < m.StallCurrentA {
-			out = append(out, withLocation(locs, "motor_driver.peak_per_channel_a", Finding{
+		if peakA > 0 && stallA > 0 && peakA < stallA {
+			msg := fmt.Sprintf(
+				"%s.peak_per_channel_a %.2fA < %s stall %.2fA (per channel)",
+				alloc.Entry.Path,
+				drv.PeakPerChA,
+				ch.label(),
+				stallA,
+			)
+			if ch.paralleled() {
+				msg = fmt.Sprintf(
+					"%s %s paralleled peak %.2fA (%.2fA x %d x %.2f derating) < %s stall %.2fA",
+					alloc.Entry.Label(),
+					ch.name(),
+					peakA,
+					drv.PeakPerChA,
+					len(ch.Channels),
+					parallelDerating(drv),
+					ch.label(),
+					stallA,
+				)
+			}
+			out = append(out, withLocation(locs, alloc.Entry.Path+".peak_per_channel_a", Finding{
 				Severity: SevError,
 				Code:     "DRV_PEAK_LT_STALL",
-				Message: fmt.Sprintf(
-					"motor_driver.peak_per_channel_a %.2fA < motor %s stall %.2fA (per channel)",
-					spec.Driver.PeakPerChA,
-					m.Name,
-					m.StallCurrentA,
-				),
+				Message:  msg,
 			}))
 		}
-		// Continuous should exceed nominal with margin
+		// Continuous should exceed nominal with margin. A thermally derated rating
+		// already accounts for heating, so it only has to cover the nominal load.
 		margin := 1.25
-		if spec.Driver.ContinuousPerChA > 0 && m.NominalCurrentA > 0 &&
-			spec.Driver.ContinuousPerChA < margin*m.NominalCurrentA {
-			out = append(out, withLocation(locs, "motor_driver.continuous_per_channel_a", Finding{
+		rating := "driver continuous rating"
+		if thermal {
+			margin = 1
+			rating = "thermally derated continuous rating"
+		}
+		where := ""
+		if ch.paralleled() {
+			where = " on " + ch.name()
+		}
+		if continuousA > 0 && nominalA > 0 &&
+			continuousA < margin*nominalA {
+			out = append(out, withLocation(locs, alloc.Entry.Path+".continuous_per_channel_a", Finding{
 				Severity: SevWarn,
 				Code:     "DRV_CONT_LOW_MARGIN",
 				Message: fmt.Sprintf(
-					"driver continuous rating %.2fA is below recommended %.2fA for motor %s (nominal %.2fA). Risk of overheating or current limiting under sustained load.",
-					spec.Driver.ContinuousPerChA,
-					margin*m.NominalCurrentA,
-					m.Name,
-					m.NominalCurrentA,
+					"%s %.2fA%s is below recommended %.2fA for %s (nominal %.2fA). Risk of overheating or current limiting under sustained load.",
+					rating,
+					continuousA,
+					where,
+					margin*nominalA,
+					ch.label(),
+					nominalA,
 				),
 			}))
 		}
@@ -181,32 +261,72 @@ func ruleDriverCurrentHeadroom(spec model.RobotSpec, locs map[string]Location) [
 }
 
 func ruleLogicVoltageCompat(spec model.RobotSpec, locs map[string]Location) []Finding {
-	lv := spec.Power.Rail.VoltageV
+	var out []Finding
+	seen := make(map[string]bool)
+	for _, e := range driverEntries(spec) {
+		for _, f := range logicVoltageCompatFor(spec, e, locs) {
+			// Drivers sharing a rail report rail-level problems once.
+			key := f.Code + f.Path + f.Message
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+func logicVoltageCompatFor(spec model.RobotSpec, e driverEntry, locs map[string]Location) []Finding {
+	drv := e.Driver
+	rail, ok := logicSupply(spec, drv.LogicRail)
+	if !ok {
+		return nil
+	}
+	lv := rail.VoltageV
 	if lv < 0 {
-		return []Finding{withLocation(locs, "power.logic_rail.voltage_v", Finding{
+		return []Finding{withLocation(locs, rail.Path, Finding{
 			Severity: SevError,
 			Code:     "RAIL_V_INVALID",
-			Message:  "power.logic_rail.voltage_v must be > 0",
+			Message:  rail.Path + " must be > 0",
 		})}
 	} else if lv == 0 {
 		return nil
 	}
-	if spec.Driver.LogicVoltageMinV == 0 || spec.Driver.LogicVoltageMaxV == 0 {
+	if drv.LogicVoltageMinV == 0 || drv.LogicVoltageMaxV == 0 {
 		return nil
 	}
-	if lv < spec.Driver.LogicVoltageMinV || lv > spec.Driver.LogicVoltageMaxV {
-		return []Finding{withLocation(locs, "power.logic_rail.voltage_v", Finding{
+	if lv < drv.LogicVoltageMinV || lv > drv.LogicVoltageMaxV {
+		return []Finding{withLocation(locs, rail.Path, Finding{
 			Severity: SevError,
 			Code:     "LOGIC_V_DRIVER_MISMATCH",
 			Message: fmt.Sprintf(
-				"logic rail %.2fV outside motor_driver logic range [%.2f, %.2f]V",
+				"%s %.2fV outside %s logic range [%.2f, %.2f]V",
+				rail.Label(),
 				lv,
-				spec.Driver.LogicVoltageMinV,
-				spec.Driver.LogicVoltageMaxV,
+				e.Path,
+				drv.LogicVoltageMinV,
+				drv.LogicVoltageMaxV,
 			),
 		})}
 	}
 	if spec.MCU.LogicVoltageV > 0 && math.Abs(spec.MCU.LogicVoltageV-lv) > 0.25 {
+		mcuV := spec.MCU.LogicVoltageV
+		if r, routed, works := shiftStatus(spec, e.Path, e.Driver.Name); routed {
+			// A broken route or an MCU level outside the driver window is reported by
+			// ruleLogicLevelMisMatch.
+			if !works || mcuV < drv.LogicVoltageMinV || mcuV > drv.LogicVoltageMaxV {
+				return nil
+			}
+			return []Finding{withLocation(locs, r.Path, Finding{
+				Severity: SevInfo,
+				Code:     "LOGIC_LEVEL_SHIFTED",
+				Message:  fmt.Sprintf("MCU logic %.2fV differs from rail %.2fV, resolved via shifter %s", mcuV, lv, r.Shifter.Name),
+			})}
+		}
+		if driverLevelsKnown(spec, e) {
+			return nil
+		}
 		return []Finding{withLocation(locs, "mcu.logic_voltage_v", Finding{
 			Severity: SevWarn,
 			Code:     "LOGIC_V_MCU_MISMATCH",
@@ -221,6 +341,10 @@ func ruleLogicVoltageCompat(spec model.RobotSpec, locs map[string]Location) []Fi
 }
 
 func ruleRailCurrentBudget(spec model.RobotSpec, locs map[string]Location) []Finding {
+	// Specs that describe power.rails instead of the single logic rail are budgeted by ruleRailLoads.
+	if len(spec.Power.Rails) > 0 && spec.Power.Rail == (model.Rail{}) {
+		return nil
+	}
 	railMax := spec.Power.Rail.MaxCurrentA
 	if railMax <= 0 {
 		return []Finding{withLocation(locs, "power.logic_rail.max_current_a", Finding{
@@ -229,47 +353,92 @@ func ruleRailCurrentBudget(spec model.RobotSpec, locs map[string]Location) []Fin
 			Message:  "power.logic_rail.max_current_a not set, cannot budget logic rail current",
 		})}
 	}
-	// For v1 we do not model currents precisely. We just nudge the user.
-	return []Finding{withLocation(locs, "power.logic_rail.max_current_a", Finding{
+
+	consumers := 0
+	for _, c := range logicConsumers(spec) {
+		if c.Rail == legacyLogicRail && supplyLoad(c.Current).PeakA > 0 {
+			consumers++
+		}
+	}
+	if consumers == 0 {
+		return []Finding{withLocation(locs, "power.logic_rail.max_current_a", Finding{
+			Severity: SevInfo,
+			Code:     "RAIL_BUDGET_NOTE",
+			Message:  fmt.Sprintf("logic rail budget set to %.2fA. No MCU, driver logic or sensor supply currents declared.", railMax),
+		})}
+	}
+
+	load := railLoads(spec)[legacyLogicRail]
+	path := "power.logic_rail.max_current_a"
+	switch {
+	case load.ContinuousA > railMax:
+		return []Finding{withLocation(locs, path, Finding{
+			Severity: SevError,
+			Code:     "RAIL_OVERLOAD",
+			Message:  fmt.Sprintf("logic rail typical load %.2fA from %d consumer(s) exceeds max %.2fA", load.ContinuousA, consumers, railMax),
+		})}
+	case load.PeakA > railMax:
+		return []Finding{withLocation(locs, path, Finding{
+			Severity: SevWarn,
+			Code:     "RAIL_PEAK_OVERLOAD",
+			Message:  fmt.Sprintf("logic rail peak load %.2fA from %d consumer(s) exceeds max %.2fA, expect brownout on bursts", load.PeakA, consumers, railMax),
+		})}
+	case load.ContinuousA >= 0.8*railMax:
+		return []Finding{withLocation(locs, path, Finding{
+			Severity: SevWarn,
+			Code:     "RAIL_MARGIN_LOW",
+			Message:  fmt.Sprintf("logic rail typical load %.2fA is close to max %.2fA", load.ContinuousA, railMax),
+		})}
+	}
+	return []Finding{withLocation(locs, path, Finding{
 		Severity: SevInfo,
-		Code:     "RAIL_BUDGET_NOTE",
-		Message:  fmt.Sprintf("logic rail budget set to %.2fA. v1 does not estimate MCU and driver logic current yet.", railMax),
+		Code:     "RAIL_BUDGET_OK",
+		Message:  fmt.Sprintf("logic rail load %.2fA typical, %.2fA peak from %d consumer(s) within %.2fA", load.ContinuousA, load.PeakA, consumers, railMax),
 	})}
 }
 
 func ruleLogicLevelMisMatch(spec model.RobotSpec, locs map[string]Location) []Finding {
 	mcuLogicV := spec.MCU.LogicVoltageV
-	driverMinV := spec.Driver.LogicVoltageMinV
-	driverMaxV := spec.Driver.LogicVoltageMaxV
-
-	var out []Finding
 	// Validate voltages before comparing logic levels.
 	if mcuLogicV < 0 {
-		out = append(out, withLocation(locs, "mcu.logic_voltage_v", Finding{
+		return []Finding{withLocation(locs, "mcu.logic_voltage_v", Finding{
 			Severity: SevError,
 			Code:     "MCU_LOGIC_V_INVALID",
 			Message:  "mcu.logic_voltage_v must be > 0",
-		}))
+		})}
+	}
+	var out []Finding
+	for _, e := range driverEntries(spec) {
+		out = append(out, logicLevelMisMatchFor(spec, e, locs)...)
 	}
+	return out
+}
+
+func logicLevelMisMatchFor(spec model.RobotSpec, e driverEntry, locs map[string]Location) []Finding {
+	mcuLogicV := spec.MCU.LogicVoltageV
+	driverMinV := e.Driver.LogicVoltageMinV
+	driverMaxV := e.Driver.LogicVoltageMaxV
+
+	var out []Finding
 	if driverMinV < 0 {
-		out = append(out, withLocation(locs, "motor_driver.logic_voltage_min_v", Finding{
+		out = append(out, withLocation(locs, e.Path+".logic_voltage_min_v", Finding{
 			Severity: SevError,
 			Code:     "DRV_LOGIC_MIN_V_INVALID",
-			Message:  "motor_driver.logic_voltage_min_v must be > 0",
+			Message:  e.Path + ".logic_voltage_min_v must be > 0",
 		}))
 	}
 	if driverMaxV < 0 {
-		out = append(out, withLocation(locs, "motor_driver.logic_voltage_max_v", Finding{
+		out = append(out, withLocation(locs, e.Path+".logic_voltage_max_v", Finding{
 			Severity: SevError,
 			Code:     "DRV_LOGIC_MAX_V_INVALID",
-			Message:  "motor_driver.logic_voltage_max_v must be > 0",
+			Message:  e.Path + ".logic_voltage_max_v must be > 0",
 		}))
 	}
 	if driverMinV > 0 && driverMaxV > 0 && driverMinV > driverMaxV {
-		out = append(out, withLocation(locs, "motor_driver.logic_voltage_min_v", Finding{
+		out = append(out, withLocation(locs, e.Path+".logic_voltage_min_v", Finding{
 			Severity: SevError,
 			Code:     "DRV_LOGIC_RANGE_INVALID",
-			Message:  "motor_driver.logic_voltage_min_v must be <= motor_driver.logic_voltage_max_v",
+			Message:  fmt.Sprintf("%[1]s.logic_voltage_min_v must be <= %[1]s.logic_voltage_max_v", e.Path),
 		}))
 	}
 	if len(out) > 0 {
@@ -279,13 +448,34 @@ func ruleLogicLevelMisMatch(spec model.RobotSpec, locs map[string]Location) []Fi
 		return nil
 	}
 
+	if r, routed, works := shiftStatus(spec, e.Path, e.Driver.Name); routed {
+		in := shiftedInput{
+			Code:  "LOGIC_LEVEL_MISMATCH",
+			Label: e.Label(),
+			MinV:  driverMinV,
+			MaxV:  driverMaxV,
+			Path:  "mcu.logic_voltage_v",
+		}
+		if mcuLogicV < driverMinV || mcuLogicV > driverMaxV {
+			in.Mismatch = fmt.Sprintf(
+				"MCU logic %.2fV outside %s logic window [%.2f, %.2f]V",
+				mcuLogicV, e.Label(), driverMinV, driverMaxV,
+			)
+		}
+		return shiftedLevelFor(spec, in, r, works, locs)
+	}
+	// Declared thresholds say more than the supply window; ruleLogicThresholds reports.
+	if driverLevelsKnown(spec, e) {
+		return nil
+	}
 	if mcuLogicV < driverMinV || mcuLogicV > driverMaxV {
 		return []Finding{withLocation(locs, "mcu.logic_voltage_v", Finding{
 			Severity: SevError,
 			Code:     "LOGIC_LEVEL_MISMATCH",
 			Message: fmt.Sprintf(
-				"MCU logic %.2fV outside driver logic window [%.2f, %.2f]V",
+				"MCU logic %.2fV outside %s logic window [%.2f, %.2f]V",
 				mcuLogicV,
+				e.Label(),
 				driverMinV,
 				driverMaxV,
 			),
@@ -294,6 +484,55 @@ func ruleLogicLevelMisMatch(spec model.RobotSpec, locs map[string]Location) []Fi
 	return nil
 }
 
+// shiftedInput is a peripheral whose logic inputs come through a level shifter.
+type shiftedInput struct {
+	Code     string // reported when the shifted level still does not fit, e.g. LOGIC_LEVEL_MISMATCH
+	Label    string
+	MinV     float64 // the peripheral's logic window, 0 when unknown
+	MaxV     float64
+	Mismatch string // why MCU logic alone does not fit, empty when it does
+	Path     string // where the unshifted mismatch is reported
+}
+
+// shiftedLevelFor checks a peripheral whose inputs come through a level shifter: it
+// sees the shifter's B side rather than MCU logic.
+func shiftedLevelFor(spec model.RobotSpec, in shiftedInput, r shiftRoute, works bool, locs map[string]Location) []Finding {
+	if !works {
+		if in.Mismatch == "" {
+			return nil
+		}
+		return []Finding{withLocation(locs, in.Path, Finding{
+			Severity: SevError,
+			Code:     in.Code,
+			Message:  fmt.Sprintf("%s and shifter %s cannot translate it (see LEVEL_SHIFTER_ROUTE)", in.Mismatch, r.Shifter.Name),
+		})}
+	}
+
+	t, _ := shiftTargetFor(spec, r.Signal.To)
+	_, bV := shiftSides(spec, r.Shifter, t)
+	if bV > 0 && (in.MinV > 0 || in.MaxV > 0) && !withinV(bV, in.MinV, in.MaxV) {
+		return []Finding{withLocation(locs, r.Path, Finding{
+			Severity: SevError,
+			Code:     in.Code,
+			Message: fmt.Sprintf(
+				"shifter %s drives %.2fV into %s logic window %s",
+				r.Shifter.Name,
+				bV,
+				in.Label,
+				voltageWindow(in.MinV, in.MaxV),
+			),
+		})}
+	}
+	if in.Mismatch == "" || bV <= 0 {
+		return nil
+	}
+	return []Finding{withLocation(locs, r.Path, Finding{
+		Severity: SevInfo,
+		Code:     "LOGIC_LEVEL_SHIFTED",
+		Message:  fmt.Sprintf("%s, mismatch resolved via shifter %s (B side %.2fV)", in.Mismatch, r.Shifter.Name, bV),
+	})}
+}
+
 func ruleBatteryCRate(spec model.RobotSpec, locs map[string]Location) []Finding {
 	cRate := spec.Power.Battery.CRating
 	maxDischargeA := spec.Power.Battery.MaxDischargeA
@@ -320,13 +559,7 @@ func ruleBatteryCRate(spec model.RobotSpec, locs map[string]Location) []Finding
 		return nil
 	}
 
-	peakCurrentA := 0.0
-	for _, motor := range spec.Motors {
-		if motor.StallCurrentA <= 0 || motor.Count <= 0 {
-			continue
-		}
-		peakCurrentA += motor.StallCurrentA * float64(motor.Count)
-	}
+	peakCurrentA := batteryPeakA(spec)
 	if batteryMaxA <= 0 || peakCurrentA <= 0 {
 		return nil
 	}
@@ -381,86 +614,49 @@ func yamlPathForRobotSpec(fields ...string) string {
 }
 
 func ruleDriverStallOverload(spec model.RobotSpec, locs map[string]Location) []Finding {
-	driverPeakPerChannelA := spec.Driver.PeakPerChA
-	driverChannels := spec.Driver.Channels
+	var out []Finding
+	for _, alloc := range allocateDrivers(spec) {
+		out = append(out, driverStallOverloadFor(spec, alloc, locs)...)
+	}
+	return out
+}
+
+func driverStallOverloadFor(spec model.RobotSpec, alloc driverAllocation, locs map[string]Location) []Finding {
+	driverPeakPerChannelA := alloc.Entry.Driver.PeakPerChA
+	driverChannels := alloc.Entry.Driver.Channels
 	driverPeakTotalA := driverPeakPerChannelA * float64(driverChannels)
-	stallTotalA := 0.0
-	hasStallData := false
+	path := alloc.Entry.Path + ".peak_per_channel_a"
 
 	// Ignore undefined (zero or negative) driver peak or channels.
 	if driverPeakPerChannelA <= 0 || driverChannels <= 0 {
 		return nil
 	}
 
-	for _, motor := range spec.Motors {
-		if motor.StallCurrentA <= 0 || motor.Count <= 0 {
-			continue
-		}
-		hasStallData = true
-		stallTotalA += motor.StallCurrentA * float64(motor.Count)
-	}
-	if !hasStallData {
+	stallTotalA := alloc.stallA(spec)
+	if stallTotalA <= 0 {
 		return nil
 	}
 
 	if stallTotalA > driverPeakTotalA {
-		return []Finding{withLocation(locs, "motor_driver.peak_per_channel_a", Finding{
+		return []Finding{withLocation(locs, path, Finding{
 			Severity: SevError,
 			Code:     "DRV_PEAK_OVERLOAD",
 			Message: fmt.Sprintf(
-				"Total motor stall %.2fA exceeds driver peak %.2fA (%.2fA per channel x %d)",
+				"Total motor stall %.2fA exceeds %s peak %.2fA (%.2fA per channel x %d)",
 				stallTotalA,
+				alloc.Entry.Label(),
 				driverPeakTotalA,
 				driverPeakPerChannelA,
 				driverChannels,
 			),
 		})}
 	} else if stallTotalA >= 0.8*driverPeakTotalA {
-		return []Finding{withLocation(locs, "motor_driver.peak_per_channel_a", Finding{
+		return []Finding{withLocation(locs, path, Finding{
 			Severity: SevWarn,
 			Code:     "DRV_PEAK_MARGIN_LOW",
-			Message:  fmt.Sprintf("Total motor stall %.2fA is close to driver peak %.2fA", stallTotalA, driverPeakTotalA),
+			Message:  fmt.Sprintf("Total motor stall %.2fA is close to %s peak %.2fA", stallTotalA, alloc.Entry.Label(), driverPeakTotalA),
 		})}
 	}
 
 	return nil
 }
-
-func ruleI2CAddressConflict(spec model.RobotSpec, locs map[string]Location) []Finding {
-	if len(spec.I2CBuses) == 0 {
-		return nil
-	}
-
-	var out []Finding
-	for busIndex, bus := range spec.I2CBuses {
-		if len(bus.Devices) == 0 {
-			continue
-		}
-		addresses := make(map[uint16][]string)
-		for _, device := range bus.Devices {
-			addr := uint16(device.AddressHex)
-			if addr == 0 {
-				continue
-			}
-			addresses[addr] = append(addresses[addr], device.Name)
-		}
-		for addr, names := range addresses {
-			if len(names) < 2 {
-				continue
-			}
-			message := fmt.Sprintf(
-				"Devices %s share I2C address 0x%X on bus %s",
-				strings.Join(names, ", "),
-				addr,
-				bus.Name,
-			)
-			path := fmt.Sprintf("i2c_buses[%d].devices", busIndex)
-			out = append(out, withLocation(locs, path, Finding{
-				Severity: SevError,
-				Code:     "I2C_ADDR_CONFLICT",
-				Message:  message,
-			}))
-		}
-	}
-	return out
-}
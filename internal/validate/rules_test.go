@@ -382,6 +382,54 @@ func TestRuleRailCurrentBudget(t *testing.T) {
 			want:   []string{"RAIL_BUDGET_NOTE"},
 			not:    []string{"RAIL_I_UNKNOWN"},
 		},
+		{
+			name: "consumers_within_budget",
+			mutate: func(s *model.RobotSpec) {
+				s.MCU.SupplyCurrent = model.SupplyCurrent{TypicalA: 0.1, PeakA: 0.35}
+				s.Driver.LogicCurrent = model.SupplyCurrent{TypicalA: 0.002}
+			},
+			want: []string{"RAIL_BUDGET_OK"},
+			not:  []string{"RAIL_BUDGET_NOTE", "RAIL_OVERLOAD", "RAIL_PEAK_OVERLOAD", "RAIL_MARGIN_LOW"},
+		},
+		{
+			name: "consumers_over_budget",
+			mutate: func(s *model.RobotSpec) {
+				s.MCU.SupplyCurrent = model.SupplyCurrent{TypicalA: 0.6}
+				s.I2CBuses = []model.I2CBus{{
+					Name: "bus0",
+					Devices: []model.I2CDevice{
+						{Name: "tof", AddressHex: 0x29, SupplyCurrent: model.SupplyCurrent{TypicalA: 0.5}},
+					},
+				}}
+			},
+			want: []string{"RAIL_OVERLOAD"},
+			not:  []string{"RAIL_BUDGET_OK"},
+		},
+		{
+			name: "consumers_peak_over_budget",
+			mutate: func(s *model.RobotSpec) {
+				s.MCU.SupplyCurrent = model.SupplyCurrent{TypicalA: 0.2, PeakA: 1.2}
+			},
+			want: []string{"RAIL_PEAK_OVERLOAD"},
+			not:  []string{"RAIL_OVERLOAD"},
+		},
+		{
+			name: "consumers_close_to_budget",
+			mutate: func(s *model.RobotSpec) {
+				s.MCU.SupplyCurrent = model.SupplyCurrent{TypicalA: 0.85}
+			},
+			want: []string{"RAIL_MARGIN_LOW"},
+		},
+		{
+			name: "consumers_on_other_rail_ignored",
+			mutate: func(s *model.RobotSpec) {
+				s.Power.Rails = []model.PowerRail{{Name: "3v3", Regulator: "buck", VoltageV: 3.3, MaxCurrentA: 2}}
+				s.MCU.Rail = "3v3"
+				s.MCU.SupplyCurrent = model.SupplyCurrent{TypicalA: 1.5}
+			},
+			want: []string{"RAIL_BUDGET_NOTE"},
+			not:  []string{"RAIL_OVERLOAD"},
+		},
 	}
 
 	for _, tt := range tests {
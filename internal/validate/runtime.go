@@ -0,0 +1,114 @@
+package validate
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/badimirzai/robotics-verifier-cli/internal/model"
+)
+
+// runtimeState is one motor state of the duty cycle profile with its battery current.
+type runtimeState struct {
+	Name     string
+	Pct      float64
+	BatteryA float64
+}
+
+// batteryStateA returns the battery current for a set of direct loads, using pick to
+// select the current of each load. Rail demand is converted through the regulator tree.
+// The legacy logic rail has no declared regulator and is counted at its output current.
+func batteryStateA(spec model.RobotSpec, direct map[string]railLoad, pick func(railLoad) float64) float64 {
+	state := make(map[string]railLoad, len(direct))
+	for name, l := range direct {
+		state[name] = railLoad{ContinuousA: pick(l)}
+	}
+	return railLoadsFrom(spec, state)[batterySource].ContinuousA + state[legacyLogicRail].ContinuousA
+}
+
+// runtimeStates computes the battery current while idle, cruising and stalled. Logic
+// loads, servo idle current and stepper holding current are drawn in every state.
+// Brushless drives have no nominal rating and count at max current while moving.
+func runtimeStates(spec model.RobotSpec) []runtimeState {
+	direct := directLoads(spec)
+	motors := dcMotorLoads(spec)
+	idle := make(map[string]railLoad, len(direct))
+	for name, l := range direct {
+		idle[name] = railLoad{ContinuousA: l.ContinuousA - motors[name].ContinuousA}
+	}
+	bldcA := bldcPeakA(spec)
+	d := spec.DutyCycle
+	continuous := func(l railLoad) float64 { return l.ContinuousA }
+	peak := func(l railLoad) float64 { return l.PeakA }
+	return []runtimeState{
+		{Name: "idle", Pct: d.IdlePct, BatteryA: batteryStateA(spec, idle, continuous)},
+		{Name: "cruise", Pct: d.CruisePct, BatteryA: batteryStateA(spec, direct, continuous) + bldcA},
+		{Name: "stall", Pct: d.StallPct, BatteryA: batteryStateA(spec, direct, peak) + bldcA},
+	}
+}
+
+// EstimateRuntime estimates battery runtime from capacity_ah and the duty_cycle profile.
+// It reports through findings so the check renderers can be reused.
+func EstimateRuntime(spec model.RobotSpec, locs map[string]Location) Report {
+	var r Report
+	d := spec.DutyCycle
+	sum := d.IdlePct + d.CruisePct + d.StallPct
+	if d.IdlePct < 0 || d.CruisePct < 0 || d.StallPct < 0 || math.Abs(sum-100) > 0.5 {
+		r.Findings = append(r.Findings, withLocation(locs, "duty_cycle", Finding{
+			Severity: SevError,
+			Code:     "RUNTIME_PROFILE_INVALID",
+			Message: fmt.Sprintf(
+				"duty_cycle idle_pct, cruise_pct and stall_pct must be >= 0 and sum to 100 (got %.1f + %.1f + %.1f)",
+				d.IdlePct,
+				d.CruisePct,
+				d.StallPct,
+			),
+		}))
+	}
+	capacityAh := spec.Power.Battery.CapacityAh
+	if capacityAh <= 0 {
+		r.Findings = append(r.Findings, withLocation(locs, "power.battery", Finding{
+			Severity: SevError,
+			Code:     "RUNTIME_CAPACITY_MISSING",
+			Message:  "power.battery.capacity_ah must be > 0 to estimate runtime",
+		}))
+	}
+	if r.HasErrors() {
+		return r
+	}
+
+	averageA := 0.0
+	for _, st := range runtimeStates(spec) {
+		averageA += st.BatteryA * st.Pct / 100
+		r.Findings = append(r.Findings, withLocation(locs, "duty_cycle."+st.Name+"_pct", Finding{
+			Severity: SevInfo,
+			Code:     "RUNTIME_STATE",
+			Message:  fmt.Sprintf("%s: %.2fA from battery for %.1f%% of the time", st.Name, st.BatteryA, st.Pct),
+			Meta: map[string]any{
+				"state":     st.Name,
+				"battery_a": roundMilli(st.BatteryA),
+				"time_pct":  st.Pct,
+				"average_a": roundMilli(st.BatteryA * st.Pct / 100),
+			},
+		}))
+	}
+	if averageA <= 0 {
+		r.Findings = append(r.Findings, withLocation(locs, "duty_cycle", Finding{
+			Severity: SevWarn,
+			Code:     "RUNTIME_NO_LOAD",
+			Message:  "no battery load declared; add motor currents or supply_current to estimate runtime",
+		}))
+		return r
+	}
+	minutes := capacityAh / averageA * 60
+	r.Findings = append(r.Findings, withLocation(locs, "power.battery.capacity_ah", Finding{
+		Severity: SevInfo,
+		Code:     "RUNTIME_ESTIMATE",
+		Message:  fmt.Sprintf("estimated runtime %.0f min from %.2fAh at %.2fA average", minutes, capacityAh, averageA),
+		Meta: map[string]any{
+			"runtime_min": roundMilli(minutes),
+			"capacity_ah": capacityAh,
+			"average_a":   roundMilli(averageA),
+		},
+	}))
+	return r
+}
@@ -0,0 +1,92 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/badimirzai/robotics-verifier-cli/internal/model"
+)
+
+func runtimeSpec() model.RobotSpec {
+	spec := treeSpec()
+	spec.Power.Battery.CapacityAh = 2
+	spec.MCU.SupplyCurrent = model.SupplyCurrent{TypicalA: 0.2}
+	spec.DutyCycle = model.DutyCycle{IdlePct: 50, CruisePct: 40, StallPct: 10}
+	return spec
+}
+
+func TestEstimateRuntime(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*model.RobotSpec)
+		want   []string
+		not    []string
+	}{
+		{
+			name:   "profile_and_capacity",
+			mutate: func(s *model.RobotSpec) {},
+			want:   []string{"RUNTIME_STATE", "RUNTIME_ESTIMATE"},
+			not:    []string{"RUNTIME_PROFILE_INVALID", "RUNTIME_CAPACITY_MISSING"},
+		},
+		{
+			name: "profile_not_100",
+			mutate: func(s *model.RobotSpec) {
+				s.DutyCycle.StallPct = 20
+			},
+			want: []string{"RUNTIME_PROFILE_INVALID"},
+			not:  []string{"RUNTIME_ESTIMATE"},
+		},
+		{
+			name: "no_capacity",
+			mutate: func(s *model.RobotSpec) {
+				s.Power.Battery.CapacityAh = 0
+			},
+			want: []string{"RUNTIME_CAPACITY_MISSING"},
+		},
+		{
+			name: "no_loads",
+			mutate: func(s *model.RobotSpec) {
+				s.Motors = nil
+				s.MCU.SupplyCurrent = model.SupplyCurrent{}
+			},
+			want: []string{"RUNTIME_NO_LOAD"},
+			not:  []string{"RUNTIME_ESTIMATE"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := runtimeSpec()
+			tt.mutate(&spec)
+			codes := reportCodes(EstimateRuntime(spec, nil))
+			for _, code := range tt.want {
+				requireHasCode(t, codes, code)
+			}
+			for _, code := range tt.not {
+				requireNoCode(t, codes, code)
+			}
+		})
+	}
+}
+
+func TestEstimateRuntimeMinutes(t *testing.T) {
+	spec := runtimeSpec()
+	// MCU 0.2A on 3v3 (LDO) -> 5v buck at 90% from 12V: 0.2*5/(12*0.9) A in every state.
+	logicA := 0.2 * 5 / (12 * 0.9)
+	// Two motors at 1A nominal and 5A stall on the battery.
+	wantA := 0.5*logicA + 0.4*(logicA+2) + 0.1*(logicA+10)
+	var got map[string]any
+	for _, f := range EstimateRuntime(spec, nil).Findings {
+		if f.Code == "RUNTIME_ESTIMATE" {
+			got = f.Meta
+		}
+	}
+	if got == nil {
+		t.Fatalf("expected RUNTIME_ESTIMATE")
+	}
+	if avg := got["average_a"].(float64); avg != roundMilli(wantA) {
+		t.Fatalf("average = %.4fA, want %.4fA", avg, roundMilli(wantA))
+	}
+	if mins := got["runtime_min"].(float64); mins != roundMilli(2/wantA*60) {
+		t.Fatalf("runtime = %.3f min, want %.3f", mins, roundMilli(2/wantA*60))
+	}
+}
@@ -0,0 +1,233 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/badimirzai/robotics-verifier-cli/internal/model"
+)
+
+func findPWMController(spec model.RobotSpec, name string) (int, bool) {
+	for i, c := range spec.PWM {
+		if c.Name == name {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+func findI2CBus(spec model.RobotSpec, name string) (int, bool) {
+	for i, bus := range spec.I2CBuses {
+		if bus.Name == name {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// pwmControllerRail returns the rail powering a controller: its own, then its bus rail.
+func pwmControllerRail(spec model.RobotSpec, c model.PWMController) string {
+	if c.Rail != "" {
+		return c.Rail
+	}
+	if idx, ok := findI2CBus(spec, c.Bus); ok {
+		return spec.I2CBuses[idx].Rail
+	}
+	return ""
+}
+
+// servoSignalSource returns the pulse high level a servo sees and who drives it. PWM
+// controllers drive their outputs at their supply voltage.
+func servoSignalSource(spec model.RobotSpec, sv model.Servo) (float64, string, bool) {
+	if sv.Controller == "" {
+		return spec.MCU.LogicVoltageV, "MCU logic", true
+	}
+	idx, ok := findPWMController(spec, sv.Controller)
+	if !ok {
+		return 0, "", false
+	}
+	rail, ok := logicSupply(spec, pwmControllerRail(spec, spec.PWM[idx]))
+	if !ok {
+		return 0, "", false
+	}
+	return rail.VoltageV, "controller " + sv.Controller, true
+}
+
+func ruleServoSupply(spec model.RobotSpec, locs map[string]Location) []Finding {
+	var out []Finding
+	for _, sv := range spec.Servos {
+		if w, ok := servoSupplyWindow(spec, sv); ok && w.outside(w.loadedV()) {
+			out = append(out, w.finding(locs, SevError, w.loadedV(), ""))
+		}
+	}
+	return out
+}
+
+// ruleServoStall compares the servos' combined stall current with each rail powering
+// them. Grippers and arms routinely hold every servo against a load at once.
+func ruleServoStall(spec model.RobotSpec, locs map[string]Location) []Finding {
+	type railServos struct {
+		supply supplyPoint
+		stallA float64
+		count  int
+	}
+	var order []string
+	byRail := make(map[string]*railServos)
+	for _, sv := range spec.Servos {
+		supply, ok := motorSupply(spec, sv.SupplyRail)
+		if !ok || sv.StallCurrentA <= 0 {
+			continue
+		}
+		rs, seen := byRail[supply.Name]
+		if !seen {
+			rs = &railServos{supply: supply}
+			byRail[supply.Name] = rs
+			order = append(order, supply.Name)
+		}
+		rs.stallA += sv.StallCurrentA * float64(sv.Count)
+		rs.count += sv.Count
+	}
+
+	var out []Finding
+	for _, name := range order {
+		rs := byRail[name]
+		maxA := rs.supply.MaxCurrentA
+		if maxA <= 0 {
+			continue
+		}
+		path := railMaxCurrentPath(rs.supply)
+		if rs.stallA > maxA {
+			out = append(out, withLocation(locs, path, Finding{
+				Severity: SevError,
+				Code:     "SERVO_STALL_OVER_RAIL",
+				Message: fmt.Sprintf(
+					"%d servo(s) stalling draw %.2fA, exceeding %s rating %.2fA",
+					rs.count,
+					rs.stallA,
+					rs.supply.Label(),
+					maxA,
+				),
+			}))
+		} else if rs.stallA >= 0.8*maxA {
+			out = append(out, withLocation(locs, path, Finding{
+				Severity: SevWarn,
+				Code:     "SERVO_STALL_MARGIN_LOW",
+				Message: fmt.Sprintf(
+					"%d servo(s) stalling draw %.2fA, close to %s rating %.2fA",
+					rs.count,
+					rs.stallA,
+					rs.supply.Label(),
+					maxA,
+				),
+			}))
+		}
+	}
+	return out
+}
+
+// railMaxCurrentPath points at the max_current_a field of a supply.
+func railMaxCurrentPath(s supplyPoint) string {
+	if strings.HasSuffix(s.Path, ".voltage_v") {
+		return strings.TrimSuffix(s.Path, ".voltage_v") + ".max_current_a"
+	}
+	return s.Path
+}
+
+func ruleServoSignal(spec model.RobotSpec, locs map[string]Location) []Finding {
+	var out []Finding
+	for i, sv := range spec.Servos {
+		base := fmt.Sprintf("servos[%d]", i)
+		if sv.Controller != "" {
+			if _, ok := findPWMController(spec, sv.Controller); !ok {
+				out = append(out, withLocation(locs, base+".controller", Finding{
+					Severity: SevError,
+					Code:     "SERVO_CONTROLLER_UNKNOWN",
+					Message:  fmt.Sprintf("servo %s references unknown PWM controller %q", sv.Name, sv.Controller),
+				}))
+				continue
+			}
+		}
+		v, source, ok := servoSignalSource(spec, sv)
+		if !ok || v <= 0 {
+			continue
+		}
+		if sv.SignalMinV > 0 && v < sv.SignalMinV {
+			out = append(out, withLocation(locs, base+".signal_min_v", Finding{
+				Severity: SevError,
+				Code:     "SERVO_SIGNAL_LOW",
+				Message: fmt.Sprintf(
+					"%s %.2fV is below servo %s signal minimum %.2fV",
+					source,
+					v,
+					sv.Name,
+					sv.SignalMinV,
+				),
+			}))
+		}
+		if sv.SignalMaxV > 0 && v > sv.SignalMaxV {
+			out = append(out, withLocation(locs, base+".signal_max_v", Finding{
+				Severity: SevError,
+				Code:     "SERVO_SIGNAL_HIGH",
+				Message: fmt.Sprintf(
+					"%s %.2fV exceeds servo %s signal maximum %.2fV",
+					source,
+					v,
+					sv.Name,
+					sv.SignalMaxV,
+				),
+			}))
+		}
+	}
+	return out
+}
+
+func rulePWMControllers(spec model.RobotSpec, locs map[string]Location) []Finding {
+	var out []Finding
+	for i, c := range spec.PWM {
+		base := fmt.Sprintf("pwm_controllers[%d]", i)
+		if _, ok := findI2CBus(spec, c.Bus); !ok {
+			out = append(out, withLocation(locs, base+".bus", Finding{
+				Severity: SevError,
+				Code:     "PWM_BUS_UNKNOWN",
+				Message:  fmt.Sprintf("PWM controller %s references unknown I2C bus %q", c.Name, c.Bus),
+			}))
+		}
+
+		used := 0
+		for _, sv := range spec.Servos {
+			if sv.Controller == c.Name {
+				used += sv.Count
+			}
+		}
+		if c.Channels > 0 && used > c.Channels {
+			out = append(out, withLocation(locs, base+".channels", Finding{
+				Severity: SevError,
+				Code:     "PWM_CHANNELS_INSUFFICIENT",
+				Message:  fmt.Sprintf("%d servo(s) assigned but PWM controller %s has %d channels", used, c.Name, c.Channels),
+			}))
+		}
+
+		if c.LogicVoltageMinV <= 0 || c.LogicVoltageMaxV <= 0 {
+			continue
+		}
+		rail, ok := logicSupply(spec, pwmControllerRail(spec, c))
+		if !ok || rail.VoltageV <= 0 {
+			continue
+		}
+		if rail.VoltageV < c.LogicVoltageMinV || rail.VoltageV > c.LogicVoltageMaxV {
+			out = append(out, withLocation(locs, rail.Path, Finding{
+				Severity: SevError,
+				Code:     "LOGIC_V_DRIVER_MISMATCH",
+				Message: fmt.Sprintf(
+					"%s %.2fV outside %s logic range [%.2f, %.2f]V",
+					rail.Label(),
+					rail.VoltageV,
+					base,
+					c.LogicVoltageMinV,
+					c.LogicVoltageMaxV,
+				),
+			}))
+		}
+	}
+	return out
+}
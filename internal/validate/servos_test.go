@@ -0,0 +1,129 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/badimirzai/robotics-verifier-cli/internal/model"
+)
+
+func servoSpec() model.RobotSpec {
+	spec := baseSpec()
+	spec.MCU.LogicVoltageV = 3.3
+	spec.Power.Rail = model.Rail{VoltageV: 3.3, MaxCurrentA: 1}
+	spec.Power.Rails = []model.PowerRail{
+		{Name: "servo_5v", Source: "battery", Regulator: "buck", VoltageV: 5, Efficiency: 0.9, MaxCurrentA: 3},
+	}
+	spec.Driver.LogicVoltageMinV = 2.7
+	spec.I2CBuses = []model.I2CBus{{
+		Name:    "i2c0",
+		Devices: []model.I2CDevice{{Name: "imu", AddressHex: 0x68}},
+	}}
+	spec.PWM = []model.PWMController{{
+		Name: "pwm0", Bus: "i2c0", AddressHex: 0x40, Channels: 16,
+		LogicVoltageMinV: 2.3, LogicVoltageMaxV: 5.5,
+	}}
+	spec.Servos = []model.Servo{{
+		Name: "gripper", Count: 2, SupplyRail: "servo_5v", Controller: "pwm0",
+		VoltageMinV: 4.8, VoltageMaxV: 6, IdleCurrentA: 0.01, StallCurrentA: 0.65,
+		SignalMinV: 2.5, SignalMaxV: 6,
+	}}
+	return spec
+}
+
+func TestRuleServos(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*model.RobotSpec)
+		want   []string
+		not    []string
+	}{
+		{
+			name:   "gripper_on_servo_rail",
+			mutate: func(s *model.RobotSpec) {},
+			not: []string{
+				"SERVO_SUPPLY_RANGE", "SERVO_STALL_OVER_RAIL", "SERVO_STALL_MARGIN_LOW", "SERVO_SIGNAL_LOW",
+				"SERVO_SIGNAL_HIGH", "PWM_BUS_UNKNOWN", "PWM_CHANNELS_INSUFFICIENT", "I2C_ADDR_CONFLICT", "RAIL_UNKNOWN",
+			},
+		},
+		{
+			name: "servos_on_battery",
+			mutate: func(s *model.RobotSpec) {
+				s.Servos[0].SupplyRail = ""
+			},
+			want: []string{"SERVO_SUPPLY_RANGE"},
+		},
+		{
+			name: "stall_over_rail",
+			mutate: func(s *model.RobotSpec) {
+				s.Servos[0].StallCurrentA = 2.5
+			},
+			want: []string{"SERVO_STALL_OVER_RAIL"},
+		},
+		{
+			name: "stall_close_to_rail",
+			mutate: func(s *model.RobotSpec) {
+				s.Servos[0].StallCurrentA = 1.3
+			},
+			want: []string{"SERVO_STALL_MARGIN_LOW"},
+			not:  []string{"SERVO_STALL_OVER_RAIL"},
+		},
+		{
+			name: "controller_on_3v3_below_servo_signal",
+			mutate: func(s *model.RobotSpec) {
+				s.Servos[0].SignalMinV = 3.5
+			},
+			want: []string{"SERVO_SIGNAL_LOW"},
+		},
+		{
+			name: "mcu_driven_servo",
+			mutate: func(s *model.RobotSpec) {
+				s.Servos[0].Controller = ""
+				s.Servos[0].SignalMaxV = 3
+			},
+			want: []string{"SERVO_SIGNAL_HIGH"},
+		},
+		{
+			name: "unknown_controller",
+			mutate: func(s *model.RobotSpec) {
+				s.Servos[0].Controller = "pwm1"
+			},
+			want: []string{"SERVO_CONTROLLER_UNKNOWN"},
+		},
+		{
+			name: "controller_counts_as_i2c_device",
+			mutate: func(s *model.RobotSpec) {
+				s.PWM[0].AddressHex = 0x68
+			},
+			want: []string{"I2C_ADDR_CONFLICT"},
+		},
+		{
+			name: "controller_on_unknown_bus",
+			mutate: func(s *model.RobotSpec) {
+				s.PWM[0].Bus = "i2c1"
+			},
+			want: []string{"PWM_BUS_UNKNOWN"},
+		},
+		{
+			name: "too_many_servos",
+			mutate: func(s *model.RobotSpec) {
+				s.Servos[0].Count = 17
+				s.Servos[0].StallCurrentA = 0
+			},
+			want: []string{"PWM_CHANNELS_INSUFFICIENT"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := servoSpec()
+			tt.mutate(&spec)
+			codes := reportCodes(RunAll(spec, nil))
+			for _, c := range tt.want {
+				requireHasCode(t, codes, c)
+			}
+			for _, c := range tt.not {
+				requireNoCode(t, codes, c)
+			}
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package validate
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/badimirzai/robotics-verifier-cli/internal/model"
+)
+
+// ioOverdriveV is how far a peripheral output may sit above MCU logic before it
+// overdrives an input that is not 5V tolerant.
+const ioOverdriveV = 0.3
+
+// spiClockMHz returns the SCK a device sees and the path that sets it.
+func spiClockMHz(bus model.SPIBus, busIndex, devIndex int) (float64, string) {
+	d := bus.Devices[devIndex]
+	if d.ClockMHz > 0 {
+		return d.ClockMHz, fmt.Sprintf("spi_buses[%d].devices[%d].clock_mhz", busIndex, devIndex)
+	}
+	return bus.ClockMHz, fmt.Sprintf("spi_buses[%d].clock_mhz", busIndex)
+}
+
+// spiMode returns the configured mode, else the first supported one.
+func spiMode(d model.SPIDevice) (int, bool) {
+	if d.Mode != nil {
+		return *d.Mode, true
+	}
+	if len(d.SupportedModes) > 0 {
+		return d.SupportedModes[0], true
+	}
+	return 0, false
+}
+
+// spiSupports reports whether a device can run in mode. Devices that declare
+// nothing about modes are assumed to follow the bus.
+func spiSupports(d model.SPIDevice, mode int) bool {
+	if len(d.SupportedModes) == 0 {
+		m, ok := spiMode(d)
+		return !ok || m == mode
+	}
+	return containsInt(d.SupportedModes, mode)
+}
+
+func spiDeviceRail(bus model.SPIBus, d model.SPIDevice) string {
+	if d.Rail != "" {
+		return d.Rail
+	}
+	return bus.Rail
+}
+
+func ruleSPI(spec model.RobotSpec, locs map[string]Location) []Finding {
+	var out []Finding
+	mcuLogicV := spec.MCU.LogicVoltageV
+	for busIndex, bus := range spec.SPIBuses {
+		modes := make(map[int][]string)
+		for devIndex, d := range bus.Devices {
+			base := fmt.Sprintf("spi_buses[%d].devices[%d]", busIndex, devIndex)
+
+			clock, clockPath := spiClockMHz(bus, busIndex, devIndex)
+			if clock > 0 && d.MaxClockMHz > 0 && clock > d.MaxClockMHz {
+				out = append(out, withLocation(locs, clockPath, Finding{
+					Severity: SevError,
+					Code:     "SPI_CLOCK_OVER",
+					Message: fmt.Sprintf(
+						"%s on %s runs at %.2fMHz, above its %.2fMHz max SCK. Lower the clock or set a per device clock_mhz.",
+						d.Name,
+						bus.Name,
+						clock,
+						d.MaxClockMHz,
+					),
+				}))
+			}
+
+			if d.Mode != nil && (*d.Mode < 0 || *d.Mode > 3) {
+				out = append(out, withLocation(locs, base+".mode", Finding{
+					Severity: SevError,
+					Code:     "SPI_MODE_INVALID",
+					Message:  fmt.Sprintf("%s mode %d is not an SPI mode (0..3)", d.Name, *d.Mode),
+				}))
+				continue
+			}
+			if d.Mode != nil && len(d.SupportedModes) > 0 && !spiSupports(d, *d.Mode) {
+				out = append(out, withLocation(locs, base+".mode", Finding{
+					Severity: SevError,
+					Code:     "SPI_MODE_UNSUPPORTED",
+					Message:  fmt.Sprintf("%s does not support SPI mode %d (supported: %v)", d.Name, *d.Mode, d.SupportedModes),
+				}))
+			}
+			if d.Mode != nil {
+				modes[*d.Mode] = append(modes[*d.Mode], d.Name)
+			}
+
+			if mcuLogicV <= 0 {
+				continue
+			}
+			in := shiftedInput{
+				Code:  "SPI_LOGIC_MISMATCH",
+				Label: d.Name,
+				MinV:  d.LogicVoltageMinV,
+				MaxV:  d.LogicVoltageMaxV,
+				Path:  base,
+			}
+			advice := ""
+			rail, ok := logicSupply(spec, spiDeviceRail(bus, d))
+			switch {
+			case d.LogicVoltageMinV > 0 && d.LogicVoltageMaxV > 0 &&
+				(mcuLogicV < d.LogicVoltageMinV || mcuLogicV > d.LogicVoltageMaxV):
+				in.Mismatch = fmt.Sprintf(
+					"MCU logic %.2fV outside %s logic window [%.2f, %.2f]V",
+					mcuLogicV,
+					d.Name,
+					d.LogicVoltageMinV,
+					d.LogicVoltageMaxV,
+				)
+			case ok && rail.VoltageV > mcuLogicV+ioOverdriveV:
+				in.Mismatch = fmt.Sprintf(
+					"%s powered from %s at %.2fV drives MISO above MCU logic %.2fV",
+					d.Name,
+					rail.Label(),
+					rail.VoltageV,
+					mcuLogicV,
+				)
+				advice = ". Power it from the MCU rail or add a level shifter."
+			}
+			if r, routed, works := shiftStatus(spec, d.Name); routed {
+				out = append(out, shiftedLevelFor(spec, in, r, works, locs)...)
+				continue
+			}
+			if in.Mismatch != "" {
+				out = append(out, withLocation(locs, base, Finding{
+					Severity: SevError,
+					Code:     "SPI_LOGIC_MISMATCH",
+					Message:  in.Mismatch + advice,
+				}))
+			}
+		}
+
+		// Devices without a configured mode follow whatever mode the bus settles on, so
+		// only configured modes that differ, or no mode every device supports, count.
+		common, hasCommon := spiCommonMode(bus.Devices)
+		if bus.Note == "" && (len(modes) > 1 || !hasCommon) && len(bus.Devices) > 1 {
+			var used []string
+			if len(modes) > 1 {
+				for m, names := range modes {
+					used = append(used, fmt.Sprintf("mode %d (%s)", m, strings.Join(names, ", ")))
+				}
+			} else {
+				for _, d := range bus.Devices {
+					if len(d.SupportedModes) > 0 {
+						used = append(used, fmt.Sprintf("%s %v", d.Name, d.SupportedModes))
+					}
+				}
+			}
+			sort.Strings(used)
+			hint := "Add a note explaining how the firmware switches modes per transaction."
+			if hasCommon {
+				hint = fmt.Sprintf("Every device supports mode %d.", common)
+			}
+			out = append(out, withLocation(locs, fmt.Sprintf("spi_buses[%d]", busIndex), Finding{
+				Severity: SevWarn,
+				Code:     "SPI_MODE_MIXED",
+				Message:  fmt.Sprintf("bus %s mixes %s. %s", bus.Name, strings.Join(used, ", "), hint),
+			}))
+		}
+	}
+	out = append(out, spiChipSelectConflicts(spec, locs)...)
+	return out
+}
+
+// spiCommonMode returns the lowest mode every device on a bus supports.
+func spiCommonMode(devices []model.SPIDevice) (int, bool) {
+	for m := 0; m <= 3; m++ {
+		all := true
+		for _, d := range devices {
+			if !spiSupports(d, m) {
+				all = false
+				break
+			}
+		}
+		if all {
+			return m, true
+		}
+	}
+	return 0, false
+}
+
+// spiChipSelectConflicts flags chip select pins used by more than one device. Pins
+// are MCU GPIOs, so a clash across buses counts too.
+func spiChipSelectConflicts(spec model.RobotSpec, locs map[string]Location) []Finding {
+	type user struct{ name, path string }
+	pins := make(map[string][]user)
+	var order []string
+	for i, bus := range spec.SPIBuses {
+		for j, d := range bus.Devices {
+			pin := strings.ToUpper(strings.TrimSpace(d.CSPin))
+			if pin == "" {
+				continue
+			}
+			if _, ok := pins[pin]; !ok {
+				order = append(order, pin)
+			}
+			pins[pin] = append(pins[pin], user{d.Name, fmt.Sprintf("spi_buses[%d].devices[%d].cs_pin", i, j)})
+		}
+	}
+	var out []Finding
+	for _, pin := range order {
+		users := pins[pin]
+		if len(users) < 2 {
+			continue
+		}
+		names := make([]string, len(users))
+		for i, u := range users {
+			names[i] = u.name
+		}
+		out = append(out, withLocation(locs, users[1].path, Finding{
+			Severity: SevError,
+			Code:     "SPI_CS_DUPLICATE",
+			Message:  fmt.Sprintf("Devices %s share chip select %s", strings.Join(names, ", "), pin),
+		}))
+	}
+	return out
+}
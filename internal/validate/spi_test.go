@@ -0,0 +1,175 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/badimirzai/robotics-verifier-cli/internal/model"
+)
+
+func spiSpec() model.RobotSpec {
+	spec := baseSpec()
+	spec.MCU.LogicVoltageV = 3.3
+	spec.Power.Rail = model.Rail{VoltageV: 3.3, MaxCurrentA: 1}
+	spec.Power.Rails = []model.PowerRail{
+		{Name: "periph_5v", Source: "battery", Regulator: "buck", VoltageV: 5, Efficiency: 0.9, MaxCurrentA: 1},
+	}
+	spec.Driver.LogicVoltageMinV = 2.7
+	spec.SPIBuses = []model.SPIBus{{
+		Name:     "spi0",
+		ClockMHz: 8,
+		Devices: []model.SPIDevice{
+			{
+				Name: "imu", CSPin: "GPIO10", SupportedModes: []int{0, 3}, MaxClockMHz: 24,
+				LogicVoltageMinV: 1.71, LogicVoltageMaxV: 3.6,
+			},
+			{
+				Name: "can", CSPin: "GPIO9", SupportedModes: []int{0, 3}, MaxClockMHz: 10,
+				LogicVoltageMinV: 2.7, LogicVoltageMaxV: 5.5,
+			},
+		},
+	}}
+	return spec
+}
+
+func spiModePtr(m int) *int { return &m }
+
+func TestRuleSPI(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*model.RobotSpec)
+		want   []string
+		not    []string
+	}{
+		{
+			name:   "imu_and_can_controller",
+			mutate: func(s *model.RobotSpec) {},
+			not: []string{
+				"SPI_CLOCK_OVER", "SPI_MODE_INVALID", "SPI_MODE_UNSUPPORTED", "SPI_MODE_MIXED",
+				"SPI_LOGIC_MISMATCH", "SPI_CS_DUPLICATE", "RAIL_UNKNOWN",
+			},
+		},
+		{
+			name: "bus_clock_above_can_controller",
+			mutate: func(s *model.RobotSpec) {
+				s.SPIBuses[0].ClockMHz = 20
+			},
+			want: []string{"SPI_CLOCK_OVER"},
+		},
+		{
+			name: "per_device_clock_keeps_can_in_range",
+			mutate: func(s *model.RobotSpec) {
+				s.SPIBuses[0].ClockMHz = 20
+				s.SPIBuses[0].Devices[1].ClockMHz = 10
+			},
+			not: []string{"SPI_CLOCK_OVER"},
+		},
+		{
+			name: "duplicate_cs",
+			mutate: func(s *model.RobotSpec) {
+				s.SPIBuses[0].Devices[1].CSPin = "gpio10"
+			},
+			want: []string{"SPI_CS_DUPLICATE"},
+		},
+		{
+			name: "duplicate_cs_across_buses",
+			mutate: func(s *model.RobotSpec) {
+				s.SPIBuses = append(s.SPIBuses, model.SPIBus{
+					Name:    "spi1",
+					Devices: []model.SPIDevice{{Name: "display", CSPin: "GPIO9"}},
+				})
+			},
+			want: []string{"SPI_CS_DUPLICATE"},
+		},
+		{
+			name: "mixed_modes_without_note",
+			mutate: func(s *model.RobotSpec) {
+				s.SPIBuses[0].Devices[0].Mode = spiModePtr(0)
+				s.SPIBuses[0].Devices[1].Mode = spiModePtr(3)
+			},
+			want: []string{"SPI_MODE_MIXED"},
+		},
+		{
+			name: "unset_mode_follows_configured_one",
+			mutate: func(s *model.RobotSpec) {
+				s.SPIBuses[0].Devices[1].Mode = spiModePtr(3)
+			},
+			not: []string{"SPI_MODE_MIXED"},
+		},
+		{
+			name: "no_common_mode",
+			mutate: func(s *model.RobotSpec) {
+				s.SPIBuses[0].Devices[1].SupportedModes = []int{1}
+			},
+			want: []string{"SPI_MODE_MIXED"},
+		},
+		{
+			name: "mixed_modes_with_note",
+			mutate: func(s *model.RobotSpec) {
+				s.SPIBuses[0].Devices[0].Mode = spiModePtr(0)
+				s.SPIBuses[0].Devices[1].Mode = spiModePtr(3)
+				s.SPIBuses[0].Note = "driver reconfigures CPOL/CPHA per transaction"
+			},
+			not: []string{"SPI_MODE_MIXED"},
+		},
+		{
+			name: "mode_not_supported",
+			mutate: func(s *model.RobotSpec) {
+				s.SPIBuses[0].Devices[0].Mode = spiModePtr(1)
+			},
+			want: []string{"SPI_MODE_UNSUPPORTED"},
+		},
+		{
+			name: "mode_out_of_range",
+			mutate: func(s *model.RobotSpec) {
+				s.SPIBuses[0].Devices[0].Mode = spiModePtr(4)
+			},
+			want: []string{"SPI_MODE_INVALID"},
+		},
+		{
+			name: "5v_mcu_on_3v3_imu",
+			mutate: func(s *model.RobotSpec) {
+				s.MCU.LogicVoltageV = 5
+			},
+			want: []string{"SPI_LOGIC_MISMATCH"},
+		},
+		{
+			name: "5v_powered_device_drives_miso",
+			mutate: func(s *model.RobotSpec) {
+				s.SPIBuses[0].Devices[1].Rail = "periph_5v"
+			},
+			want: []string{"SPI_LOGIC_MISMATCH"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := spiSpec()
+			tt.mutate(&spec)
+			codes := reportCodes(RunAll(spec, nil))
+			for _, c := range tt.want {
+				requireHasCode(t, codes, c)
+			}
+			for _, c := range tt.not {
+				requireNoCode(t, codes, c)
+			}
+		})
+	}
+}
+
+func TestSPICommonMode(t *testing.T) {
+	devices := []model.SPIDevice{
+		{Name: "imu", SupportedModes: []int{0, 3}},
+		{Name: "adc", SupportedModes: []int{1, 3}},
+	}
+	if m, ok := spiCommonMode(devices); !ok || m != 3 {
+		t.Fatalf("expected common mode 3, got %d (%v)", m, ok)
+	}
+	devices = append(devices, model.SPIDevice{Name: "flash"})
+	if m, ok := spiCommonMode(devices); !ok || m != 3 {
+		t.Fatalf("expected a device without mode data to follow mode 3, got %d (%v)", m, ok)
+	}
+	devices = append(devices, model.SPIDevice{Name: "display", Mode: spiModePtr(0)})
+	if _, ok := spiCommonMode(devices); ok {
+		t.Fatalf("expected no common mode with a fixed mode 0 device")
+	}
+}
@@ -0,0 +1,274 @@
+package validate
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/badimirzai/robotics-verifier-cli/internal/model"
+)
+
+// stepperRatedV is the voltage that drives rated current through one phase. Chopper
+// drivers need a supply above it to reach their current limit.
+func stepperRatedV(m model.StepperMotor) float64 {
+	if m.RatedVoltageV > 0 {
+		return m.RatedVoltageV
+	}
+	return m.PhaseCurrentA * m.PhaseResistanceOhm
+}
+
+// stepperPhaseA is the current a stepper runs at: the configured limit, or the motor
+// rating when no limit is declared.
+func stepperPhaseA(st model.Stepper) float64 {
+	if st.Driver.CurrentLimitA > 0 {
+		return st.Driver.CurrentLimitA
+	}
+	return st.Motor.PhaseCurrentA
+}
+
+// stepperSupplyLoad estimates what one stepper axis draws from its motor supply while
+// holding: both phases at the phase current dissipate I²R, which a chopper driver
+// delivers from the higher supply voltage. Without a phase resistance both phase
+// currents are counted as supply current.
+func stepperSupplyLoad(st model.Stepper, supplyV float64) railLoad {
+	phaseA := stepperPhaseA(st)
+	if phaseA <= 0 {
+		return railLoad{}
+	}
+	a := 2 * phaseA
+	if r := st.Motor.PhaseResistanceOhm; r > 0 && supplyV > 0 {
+		a = math.Min(a, 2*phaseA*phaseA*r/supplyV)
+	}
+	n := float64(st.Count)
+	return railLoad{ContinuousA: a * n, PeakA: a * n}
+}
+
+func ruleStepperSupply(spec model.RobotSpec, locs map[string]Location) []Finding {
+	var out []Finding
+	for i, st := range spec.Steppers {
+		w, ok := stepperSupplyWindow(spec, i, st)
+		if ok && w.outside(w.loadedV()) {
+			out = append(out, w.finding(locs, SevError, w.loadedV(), ""))
+			continue
+		}
+		supply, ok := motorSupply(spec, st.Driver.SupplyRail)
+		if !ok || supply.VoltageV <= 0 {
+			continue
+		}
+		supplyV := supply.VoltageV
+		if ratedV := stepperRatedV(st.Motor); ratedV > 0 && supplyV < ratedV {
+			out = append(out, withLocation(locs, supply.Path, Finding{
+				Severity: SevWarn,
+				Code:     "STEPPER_SUPPLY_LOW",
+				Message: fmt.Sprintf(
+					"stepper %s: %s %.2fV is below the motor rated voltage %.2fV, the driver cannot reach rated phase current",
+					st.Name,
+					supply.Label(),
+					supplyV,
+					ratedV,
+				),
+			}))
+		}
+	}
+	return out
+}
+
+func ruleStepperCurrent(spec model.RobotSpec, locs map[string]Location) []Finding {
+	var out []Finding
+	for i, st := range spec.Steppers {
+		base := fmt.Sprintf("steppers[%d]", i)
+		limitA := st.Driver.CurrentLimitA
+		ratedA := st.Motor.PhaseCurrentA
+		driverMaxA := st.Driver.MaxPhaseCurrentA
+
+		if limitA < 0 {
+			out = append(out, withLocation(locs, base+".driver.current_limit_a", Finding{
+				Severity: SevError,
+				Code:     "STEPPER_LIMIT_INVALID",
+				Message:  base + ".driver.current_limit_a must be > 0",
+			}))
+			continue
+		}
+		if limitA == 0 {
+			if ratedA > 0 && driverMaxA > 0 && ratedA > driverMaxA {
+				out = append(out, withLocation(locs, base+".driver.max_phase_current_a", Finding{
+					Severity: SevWarn,
+					Code:     "STEPPER_DRIVER_UNDERSIZED",
+					Message: fmt.Sprintf(
+						"stepper %s: driver max %.2fA per phase is below motor rating %.2fA, expect reduced torque",
+						st.Name,
+						driverMaxA,
+						ratedA,
+					),
+				}))
+			}
+			if ratedA > 0 {
+				out = append(out, withLocation(locs, base+".driver", Finding{
+					Severity: SevInfo,
+					Code:     "STEPPER_LIMIT_UNSET",
+					Message: fmt.Sprintf(
+						"stepper %s: driver current_limit_a not declared, set it to at most %.2fA before powering the motor",
+						st.Name,
+						math.Min(ratedA, positiveOr(driverMaxA, ratedA)),
+					),
+				}))
+			}
+			continue
+		}
+
+		ok := true
+		if driverMaxA > 0 && limitA > driverMaxA {
+			ok = false
+			out = append(out, withLocation(locs, base+".driver.current_limit_a", Finding{
+				Severity: SevError,
+				Code:     "STEPPER_LIMIT_OVER_DRIVER",
+				Message: fmt.Sprintf(
+					"stepper %s: current limit %.2fA exceeds driver max %.2fA per phase",
+					st.Name,
+					limitA,
+					driverMaxA,
+				),
+			}))
+		}
+		if ratedA > 0 && limitA > ratedA {
+			ok = false
+			out = append(out, withLocation(locs, base+".driver.current_limit_a", Finding{
+				Severity: SevError,
+				Code:     "STEPPER_LIMIT_OVER_MOTOR",
+				Message: fmt.Sprintf(
+					"stepper %s: current limit %.2fA exceeds motor rated phase current %.2fA. Risk of overheating the motor.",
+					st.Name,
+					limitA,
+					ratedA,
+				),
+			}))
+		}
+		if ok && ratedA > 0 {
+			out = append(out, withLocation(locs, base+".driver.current_limit_a", Finding{
+				Severity: SevInfo,
+				Code:     "STEPPER_CURRENT_OK",
+				Message: fmt.Sprintf(
+					"stepper %s: current limit %.2fA is %.0f%% of motor rating %.2fA",
+					st.Name,
+					limitA,
+					100*limitA/ratedA,
+					ratedA,
+				),
+			}))
+		}
+	}
+	return out
+}
+
+func ruleStepperMicrostep(spec model.RobotSpec, locs map[string]Location) []Finding {
+	var out []Finding
+	for i, st := range spec.Steppers {
+		base := fmt.Sprintf("steppers[%d]", i)
+		ms := st.Driver.Microsteps
+		if ms == 0 {
+			continue
+		}
+		if ms < 0 || (len(st.Driver.SupportedMicrosteps) > 0 && !containsInt(st.Driver.SupportedMicrosteps, ms)) {
+			out = append(out, withLocation(locs, base+".driver.microsteps", Finding{
+				Severity: SevError,
+				Code:     "STEPPER_MICROSTEP_UNSUPPORTED",
+				Message: fmt.Sprintf(
+					"stepper %s: driver does not support 1/%d microstepping (supported: %v)",
+					st.Name,
+					ms,
+					st.Driver.SupportedMicrosteps,
+				),
+			}))
+			continue
+		}
+		if st.Motor.StepAngleDeg > 0 {
+			out = append(out, withLocation(locs, base+".driver.microsteps", Finding{
+				Severity: SevInfo,
+				Code:     "STEPPER_RESOLUTION",
+				Message: fmt.Sprintf(
+					"stepper %s: %.2f° full step at 1/%d microstepping gives %.0f steps per revolution",
+					st.Name,
+					st.Motor.StepAngleDeg,
+					ms,
+					360/st.Motor.StepAngleDeg*float64(ms),
+				),
+			}))
+		}
+	}
+	return out
+}
+
+// ruleStepperLogic checks the driver logic supply and the MCU step/dir levels, using
+// the same codes as the DC driver logic rules.
+func ruleStepperLogic(spec model.RobotSpec, locs map[string]Location) []Finding {
+	var out []Finding
+	mcuLogicV := spec.MCU.LogicVoltageV
+	for i, st := range spec.Steppers {
+		base := fmt.Sprintf("steppers[%d]", i)
+		drv := st.Driver
+		if drv.LogicVoltageMinV <= 0 || drv.LogicVoltageMaxV <= 0 {
+			continue
+		}
+		if rail, ok := logicSupply(spec, drv.LogicRail); ok && rail.VoltageV > 0 &&
+			(rail.VoltageV < drv.LogicVoltageMinV || rail.VoltageV > drv.LogicVoltageMaxV) {
+			out = append(out, withLocation(locs, rail.Path, Finding{
+				Severity: SevError,
+				Code:     "LOGIC_V_DRIVER_MISMATCH",
+				Message: fmt.Sprintf(
+					"%s %.2fV outside %s.driver logic range [%.2f, %.2f]V",
+					rail.Label(),
+					rail.VoltageV,
+					base,
+					drv.LogicVoltageMinV,
+					drv.LogicVoltageMaxV,
+				),
+			}))
+		}
+		if mcuLogicV <= 0 {
+			continue
+		}
+		in := shiftedInput{
+			Code:  "LOGIC_LEVEL_MISMATCH",
+			Label: "stepper " + st.Name + " driver",
+			MinV:  drv.LogicVoltageMinV,
+			MaxV:  drv.LogicVoltageMaxV,
+			Path:  "mcu.logic_voltage_v",
+		}
+		if mcuLogicV < drv.LogicVoltageMinV || mcuLogicV > drv.LogicVoltageMaxV {
+			in.Mismatch = fmt.Sprintf(
+				"MCU logic %.2fV outside stepper %s driver logic window [%.2f, %.2f]V",
+				mcuLogicV,
+				st.Name,
+				drv.LogicVoltageMinV,
+				drv.LogicVoltageMaxV,
+			)
+		}
+		if r, routed, works := shiftStatus(spec, base, st.Name); routed {
+			out = append(out, shiftedLevelFor(spec, in, r, works, locs)...)
+			continue
+		}
+		if in.Mismatch != "" {
+			out = append(out, withLocation(locs, in.Path, Finding{
+				Severity: SevError,
+				Code:     in.Code,
+				Message:  in.Mismatch,
+			}))
+		}
+	}
+	return out
+}
+
+func containsInt(values []int, v int) bool {
+	for _, x := range values {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+func positiveOr(v, fallback float64) float64 {
+	if v > 0 {
+		return v
+	}
+	return fallback
+}
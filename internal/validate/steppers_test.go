@@ -0,0 +1,141 @@
+package validate
+
+import (
+	"math"
+	"testing"
+
+	"github.com/badimirzai/robotics-verifier-cli/internal/model"
+)
+
+func stepperSpec() model.RobotSpec {
+	spec := baseSpec()
+	spec.Driver = model.MotorDriver{}
+	spec.Motors = nil
+	spec.MCU.LogicVoltageV = 3.3
+	spec.Power.Rail.VoltageV = 3.3
+	spec.Steppers = []model.Stepper{{
+		Name:  "shoulder",
+		Count: 2,
+		Motor: model.StepperMotor{PhaseCurrentA: 1.7, PhaseResistanceOhm: 1.5, StepAngleDeg: 1.8},
+		Driver: model.StepperDriver{
+			MotorSupplyMinV:     8,
+			MotorSupplyMaxV:     35,
+			MaxPhaseCurrentA:    2,
+			CurrentLimitA:       1.2,
+			Microsteps:          16,
+			SupportedMicrosteps: []int{1, 2, 4, 8, 16},
+			LogicVoltageMinV:    3,
+			LogicVoltageMaxV:    5.5,
+		},
+	}}
+	return spec
+}
+
+func TestRuleSteppers(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*model.RobotSpec)
+		want   []string
+		not    []string
+	}{
+		{
+			name:   "clean_axis",
+			mutate: func(s *model.RobotSpec) {},
+			want:   []string{"STEPPER_CURRENT_OK", "STEPPER_RESOLUTION"},
+			not: []string{
+				"DRV_SUPPLY_RANGE", "STEPPER_SUPPLY_LOW", "STEPPER_LIMIT_OVER_DRIVER", "STEPPER_LIMIT_OVER_MOTOR",
+				"STEPPER_MICROSTEP_UNSUPPORTED", "LOGIC_LEVEL_MISMATCH", "LOGIC_V_DRIVER_MISMATCH", "DRV_CHANNELS_INVALID",
+			},
+		},
+		{
+			name: "supply_above_vmot",
+			mutate: func(s *model.RobotSpec) {
+				s.Power.Battery.VoltageV = 48
+			},
+			want: []string{"DRV_SUPPLY_RANGE"},
+		},
+		{
+			name: "supply_below_rated_voltage",
+			mutate: func(s *model.RobotSpec) {
+				s.Steppers[0].Driver.MotorSupplyMinV = 0
+				s.Steppers[0].Driver.MotorSupplyMaxV = 0
+				s.Steppers[0].Motor.PhaseResistanceOhm = 10
+			},
+			want: []string{"STEPPER_SUPPLY_LOW"},
+		},
+		{
+			name: "limit_over_motor_rating",
+			mutate: func(s *model.RobotSpec) {
+				s.Steppers[0].Driver.CurrentLimitA = 1.9
+			},
+			want: []string{"STEPPER_LIMIT_OVER_MOTOR"},
+			not:  []string{"STEPPER_LIMIT_OVER_DRIVER", "STEPPER_CURRENT_OK"},
+		},
+		{
+			name: "limit_over_driver_max",
+			mutate: func(s *model.RobotSpec) {
+				s.Steppers[0].Motor.PhaseCurrentA = 2.5
+				s.Steppers[0].Driver.CurrentLimitA = 2.2
+			},
+			want: []string{"STEPPER_LIMIT_OVER_DRIVER"},
+			not:  []string{"STEPPER_LIMIT_OVER_MOTOR"},
+		},
+		{
+			name: "limit_unset_undersized_driver",
+			mutate: func(s *model.RobotSpec) {
+				s.Steppers[0].Motor.PhaseCurrentA = 2.5
+				s.Steppers[0].Driver.CurrentLimitA = 0
+			},
+			want: []string{"STEPPER_LIMIT_UNSET", "STEPPER_DRIVER_UNDERSIZED"},
+		},
+		{
+			name: "unsupported_microstep",
+			mutate: func(s *model.RobotSpec) {
+				s.Steppers[0].Driver.Microsteps = 32
+			},
+			want: []string{"STEPPER_MICROSTEP_UNSUPPORTED"},
+			not:  []string{"STEPPER_RESOLUTION"},
+		},
+		{
+			name: "mcu_logic_below_driver_window",
+			mutate: func(s *model.RobotSpec) {
+				s.Steppers[0].Driver.LogicVoltageMinV = 4.5
+			},
+			want: []string{"LOGIC_LEVEL_MISMATCH", "LOGIC_V_DRIVER_MISMATCH"},
+		},
+		{
+			name: "unknown_supply_rail",
+			mutate: func(s *model.RobotSpec) {
+				s.Steppers[0].Driver.SupplyRail = "24v"
+			},
+			want: []string{"RAIL_UNKNOWN"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := stepperSpec()
+			tt.mutate(&spec)
+			codes := reportCodes(RunAll(spec, nil))
+			for _, c := range tt.want {
+				requireHasCode(t, codes, c)
+			}
+			for _, c := range tt.not {
+				requireNoCode(t, codes, c)
+			}
+		})
+	}
+}
+
+func TestStepperSupplyLoad(t *testing.T) {
+	st := stepperSpec().Steppers[0]
+	// Two phases at 1.2A through 1.5 ohm from 12V, two axes.
+	want := 2 * 2 * 1.2 * 1.2 * 1.5 / 12
+	if got := stepperSupplyLoad(st, 12).ContinuousA; math.Abs(got-want) > 1e-9 {
+		t.Fatalf("expected %.4fA, got %.4f", want, got)
+	}
+	st.Motor.PhaseResistanceOhm = 0
+	if got := stepperSupplyLoad(st, 12).ContinuousA; math.Abs(got-2*2*1.2) > 1e-9 {
+		t.Fatalf("expected phase current fallback, got %.4f", got)
+	}
+}
@@ -0,0 +1,144 @@
+package validate
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/badimirzai/robotics-verifier-cli/internal/model"
+)
+
+// defaultAmbientC is used when environment.ambient_c is not declared.
+const defaultAmbientC = 25.0
+
+func ambientC(spec model.RobotSpec) float64 {
+	if spec.Environment.AmbientC != 0 {
+		return spec.Environment.AmbientC
+	}
+	return defaultAmbientC
+}
+
+// driverThetaJA returns the junction to ambient thermal resistance in use: the
+// heatsink value when a heatsink is fitted and rated, else the bare package value.
+func driverThetaJA(d model.MotorDriver) float64 {
+	if d.Heatsink && d.ThetaJAHeatsinkCPerW > 0 {
+		return d.ThetaJAHeatsinkCPerW
+	}
+	return d.ThetaJACPerW
+}
+
+// hasThermalData reports whether a driver declares enough to estimate its junction
+// temperature.
+func hasThermalData(d model.MotorDriver) bool {
+	return driverThetaJA(d) > 0 && d.TjMaxC > 0 && hasBridgeDrop(d)
+}
+
+// groupLossW is the conduction loss of one channel group carrying currentA, split
+// evenly across paralleled channels.
+func groupLossW(d model.MotorDriver, g channelLoad, currentA float64) float64 {
+	return driverDropV(d, currentA, len(g.Channels)) * currentA
+}
+
+// driverThermal is the steady state estimate for one driver package.
+type driverThermal struct {
+	AmbientC           float64
+	ThetaJA            float64
+	PowerW             float64 // at nominal motor load
+	JunctionC          float64
+	DeratedPerChA      float64 // per channel continuous current that reaches tj_max_c with every used channel loaded equally
+	ChannelsConsidered int
+}
+
+func estimateDriverThermal(spec model.RobotSpec, alloc driverAllocation) (driverThermal, bool) {
+	drv := alloc.Entry.Driver
+	if !hasThermalData(drv) {
+		return driverThermal{}, false
+	}
+	t := driverThermal{AmbientC: ambientC(spec), ThetaJA: driverThetaJA(drv)}
+	for _, g := range alloc.Channels {
+		t.PowerW += groupLossW(drv, g, g.nominalA())
+	}
+	t.JunctionC = t.AmbientC + t.PowerW*t.ThetaJA
+
+	n := alloc.channelsUsed()
+	if n == 0 {
+		n = drv.Channels
+	}
+	if n <= 0 {
+		n = 1
+	}
+	t.ChannelsConsidered = n
+	// Each channel may dissipate fixedV·I + ohms·I² of the budget; solve for I.
+	perChW := math.Max(drv.TjMaxC-t.AmbientC, 0) / t.ThetaJA / float64(n)
+	fixedV, ohms := bridgeDrop(drv)
+	if ohms > 0 {
+		t.DeratedPerChA = (math.Sqrt(fixedV*fixedV+4*ohms*perChW) - fixedV) / (2 * ohms)
+	} else {
+		t.DeratedPerChA = perChW / fixedV
+	}
+	return t, true
+}
+
+// effectiveContinuousPerChA returns the per channel continuous rating to check loads
+// against and whether it comes from the thermal model. The thermal estimate replaces
+// the flat headroom margin, so only the lower of datasheet and derated value counts.
+func effectiveContinuousPerChA(spec model.RobotSpec, alloc driverAllocation) (float64, bool) {
+	drv := alloc.Entry.Driver
+	t, ok := estimateDriverThermal(spec, alloc)
+	if !ok {
+		return drv.ContinuousPerChA, false
+	}
+	if drv.ContinuousPerChA > 0 && drv.ContinuousPerChA < t.DeratedPerChA {
+		return drv.ContinuousPerChA, true
+	}
+	return t.DeratedPerChA, true
+}
+
+func ruleDriverThermal(spec model.RobotSpec, locs map[string]Location) []Finding {
+	var out []Finding
+	for _, alloc := range allocateDrivers(spec) {
+		drv := alloc.Entry.Driver
+		t, ok := estimateDriverThermal(spec, alloc)
+		if !ok {
+			continue
+		}
+		label := alloc.Entry.Label()
+		detail := fmt.Sprintf("%.2fW x %.1f°C/W at %.0f°C ambient", t.PowerW, t.ThetaJA, t.AmbientC)
+		allowedRise := drv.TjMaxC - t.AmbientC
+		switch {
+		case t.JunctionC > drv.TjMaxC:
+			out = append(out, withLocation(locs, alloc.Entry.Path+".tj_max_c", Finding{
+				Severity: SevError,
+				Code:     "DRV_THERMAL_OVER",
+				Message: fmt.Sprintf(
+					"%s junction estimate %.0f°C at nominal load exceeds tj_max %.0f°C (%s)",
+					label, t.JunctionC, drv.TjMaxC, detail,
+				),
+			}))
+		case t.PowerW > 0 && t.JunctionC-t.AmbientC >= 0.8*allowedRise:
+			out = append(out, withLocation(locs, alloc.Entry.Path+".tj_max_c", Finding{
+				Severity: SevWarn,
+				Code:     "DRV_THERMAL_MARGIN_LOW",
+				Message: fmt.Sprintf(
+					"%s junction estimate %.0f°C at nominal load is close to tj_max %.0f°C (%s)",
+					label, t.JunctionC, drv.TjMaxC, detail,
+				),
+			}))
+		}
+		// Small differences are within the accuracy of the model and not worth a note.
+		if drv.ContinuousPerChA > 0 && t.DeratedPerChA < 0.9*drv.ContinuousPerChA {
+			hs := ""
+			if drv.Heatsink && drv.ThetaJAHeatsinkCPerW > 0 {
+				hs = " with heatsink"
+			}
+			out = append(out, withLocation(locs, alloc.Entry.Path+".continuous_per_channel_a", Finding{
+				Severity: SevInfo,
+				Code:     "DRV_THERMAL_DERATED",
+				Message: fmt.Sprintf(
+					"%s continuous rating derated from %.2fA to %.2fA per channel with %d channel(s) loaded at %.0f°C ambient%s",
+					label, drv.ContinuousPerChA, t.DeratedPerChA, t.ChannelsConsidered, t.AmbientC, hs,
+				),
+			}))
+		}
+	}
+	return out
+}
@@ -0,0 +1,101 @@
+package validate
+
+import (
+	"math"
+	"testing"
+
+	"github.com/badimirzai/robotics-verifier-cli/internal/model"
+)
+
+// bipolarSpec loads both channels of an L298 style bridge at 1A nominal.
+func bipolarSpec() model.RobotSpec {
+	spec := baseSpec()
+	spec.Driver.SaturationDropV = 2.6
+	spec.Driver.ThetaJACPerW = 35
+	spec.Driver.ThetaJAHeatsinkCPerW = 13
+	spec.Driver.TjMaxC = 150
+	return spec
+}
+
+func TestRuleDriverThermal(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*model.RobotSpec)
+		want   []string
+		not    []string
+	}{
+		{
+			name:   "bare_package_overheats",
+			mutate: func(s *model.RobotSpec) {},
+			want:   []string{"DRV_THERMAL_OVER", "DRV_THERMAL_DERATED", "DRV_CONT_LOW_MARGIN"},
+		},
+		{
+			name: "heatsink_fitted",
+			mutate: func(s *model.RobotSpec) {
+				s.Driver.Heatsink = true
+			},
+			not: []string{"DRV_THERMAL_OVER", "DRV_THERMAL_MARGIN_LOW", "DRV_THERMAL_DERATED", "DRV_CONT_LOW_MARGIN"},
+		},
+		{
+			name: "heatsink_in_hot_enclosure",
+			mutate: func(s *model.RobotSpec) {
+				s.Driver.Heatsink = true
+				s.Environment.AmbientC = 70
+			},
+			want: []string{"DRV_THERMAL_MARGIN_LOW"},
+			not:  []string{"DRV_THERMAL_OVER"},
+		},
+		{
+			name: "mosfet_bridge",
+			mutate: func(s *model.RobotSpec) {
+				s.Driver.SaturationDropV = 0
+				s.Driver.RdsOnOhm = 0.25
+				s.Driver.ThetaJACPerW = 92
+			},
+			want: []string{"DRV_THERMAL_DERATED"},
+			not:  []string{"DRV_THERMAL_OVER", "DRV_THERMAL_MARGIN_LOW", "DRV_CONT_LOW_MARGIN"},
+		},
+		{
+			name: "no_thermal_data",
+			mutate: func(s *model.RobotSpec) {
+				s.Driver.TjMaxC = 0
+			},
+			not: []string{"DRV_THERMAL_OVER", "DRV_THERMAL_MARGIN_LOW", "DRV_THERMAL_DERATED"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := bipolarSpec()
+			tt.mutate(&spec)
+			codes := reportCodes(RunAll(spec, nil))
+			for _, code := range tt.want {
+				requireHasCode(t, codes, code)
+			}
+			for _, code := range tt.not {
+				requireNoCode(t, codes, code)
+			}
+		})
+	}
+}
+
+func TestEstimateDriverThermal(t *testing.T) {
+	spec := bipolarSpec()
+	spec.Driver.Heatsink = true
+	allocs := allocateDrivers(spec)
+	if len(allocs) != 1 {
+		t.Fatalf("expected 1 driver allocation, got %d", len(allocs))
+	}
+	got, ok := estimateDriverThermal(spec, allocs[0])
+	if !ok {
+		t.Fatalf("expected thermal estimate")
+	}
+	// 2 channels x 2.6V x 1A through 13°C/W.
+	if math.Abs(got.PowerW-5.2) > 1e-9 || math.Abs(got.JunctionC-92.6) > 1e-9 {
+		t.Fatalf("unexpected estimate: %+v", got)
+	}
+	// (150-25)/13 W shared by 2 channels at 2.6V each.
+	if want := 125.0 / 13 / (2 * 2.6); math.Abs(got.DeratedPerChA-want) > 1e-9 {
+		t.Fatalf("derated = %.4f, want %.4f", got.DeratedPerChA, want)
+	}
+}
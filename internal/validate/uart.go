@@ -0,0 +1,122 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/badimirzai/robotics-verifier-cli/internal/model"
+)
+
+// uartInstanceKey normalises instance names so "uart1" and "UART1" match.
+func uartInstanceKey(name string) string {
+	return strings.ToUpper(strings.TrimSpace(name))
+}
+
+func ruleUART(spec model.RobotSpec, locs map[string]Location) []Finding {
+	if len(spec.UARTs) == 0 {
+		return nil
+	}
+	var out []Finding
+	mcu := spec.MCU
+
+	known := make(map[string]bool)
+	for _, name := range mcu.UARTs {
+		known[uartInstanceKey(name)] = true
+	}
+
+	users := make(map[string][]int)
+	var order []string
+	for i, u := range spec.UARTs {
+		base := fmt.Sprintf("uarts[%d]", i)
+		key := uartInstanceKey(u.Instance)
+		if key != "" {
+			if _, ok := users[key]; !ok {
+				order = append(order, key)
+			}
+			users[key] = append(users[key], i)
+			if len(known) > 0 && !known[key] {
+				out = append(out, withLocation(locs, base+".uart", Finding{
+					Severity: SevError,
+					Code:     "UART_UNKNOWN",
+					Message: fmt.Sprintf(
+						"%s uses %s, which the MCU does not have (available: %s)",
+						u.Name,
+						u.Instance,
+						strings.Join(mcu.UARTs, ", "),
+					),
+				}))
+			}
+		}
+
+		if u.Baud > 0 {
+			switch {
+			case len(u.SupportedBaud) > 0 && !containsInt(u.SupportedBaud, u.Baud):
+				out = append(out, withLocation(locs, base+".baud", Finding{
+					Severity: SevError,
+					Code:     "UART_BAUD_UNSUPPORTED",
+					Message:  fmt.Sprintf("%s does not support %d baud (supported: %v)", u.Name, u.Baud, u.SupportedBaud),
+				}))
+			case mcu.UARTMaxBaud > 0 && u.Baud > mcu.UARTMaxBaud:
+				out = append(out, withLocation(locs, base+".baud", Finding{
+					Severity: SevError,
+					Code:     "UART_BAUD_UNSUPPORTED",
+					Message:  fmt.Sprintf("%s at %d baud exceeds the MCU UART maximum of %d", u.Name, u.Baud, mcu.UARTMaxBaud),
+				}))
+			}
+		}
+
+		// With thresholds on both ends ruleLogicThresholds checks the TX line instead.
+		if u.LogicVoltageV > 0 && mcu.LogicVoltageV > 0 && !mcuFiveVTolerant(mcu) &&
+			u.LogicVoltageV > mcu.LogicVoltageV+ioOverdriveV && !uartRXLevelsKnown(spec, u) {
+			if r, routed, works := shiftStatus(spec, u.Name); routed && works {
+				out = append(out, withLocation(locs, r.Path, Finding{
+					Severity: SevInfo,
+					Code:     "LOGIC_LEVEL_SHIFTED",
+					Message: fmt.Sprintf(
+						"%s %.2fV TTL above %.2fV MCU logic, mismatch resolved via shifter %s",
+						u.Name,
+						u.LogicVoltageV,
+						mcu.LogicVoltageV,
+						r.Shifter.Name,
+					),
+				}))
+			} else {
+				out = append(out, withLocation(locs, base+".logic_voltage_v", Finding{
+					Severity: SevError,
+					Code:     "UART_LOGIC_MISMATCH",
+					Message: fmt.Sprintf(
+						"%s drives %.2fV TTL into MCU RX pins rated for %.2fV logic. Add a level shifter or a divider on its TX line.",
+						u.Name,
+						u.LogicVoltageV,
+						mcu.LogicVoltageV,
+					),
+				}))
+			}
+		}
+	}
+
+	for _, key := range order {
+		idx := users[key]
+		if len(idx) < 2 {
+			continue
+		}
+		names := make([]string, len(idx))
+		for i, j := range idx {
+			names[i] = spec.UARTs[j].Name
+		}
+		out = append(out, withLocation(locs, fmt.Sprintf("uarts[%d].uart", idx[1]), Finding{
+			Severity: SevError,
+			Code:     "UART_SHARED",
+			Message:  fmt.Sprintf("Devices %s share %s; a UART connects one peripheral", strings.Join(names, ", "), spec.UARTs[idx[0]].Instance),
+		}))
+	}
+
+	if len(mcu.UARTs) > 0 && len(order) > len(mcu.UARTs) {
+		out = append(out, withLocation(locs, "uarts", Finding{
+			Severity: SevError,
+			Code:     "UART_COUNT_EXCEEDED",
+			Message:  fmt.Sprintf("%d UARTs in use but the MCU provides %d (%s)", len(order), len(mcu.UARTs), strings.Join(mcu.UARTs, ", ")),
+		}))
+	}
+	return out
+}
@@ -0,0 +1,110 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/badimirzai/robotics-verifier-cli/internal/model"
+)
+
+func uartSpec() model.RobotSpec {
+	spec := baseSpec()
+	spec.MCU.LogicVoltageV = 3.3
+	spec.MCU.UARTs = []string{"UART0", "UART1", "UART2"}
+	spec.MCU.UARTMaxBaud = 5000000
+	spec.Power.Rail = model.Rail{VoltageV: 3.3, MaxCurrentA: 1}
+	spec.Driver.LogicVoltageMinV = 2.7
+	spec.UARTs = []model.UART{
+		{
+			Name: "gps", Instance: "UART1", Baud: 9600, LogicVoltageV: 3.3,
+			SupportedBaud: []int{4800, 9600, 19200, 38400, 57600, 115200},
+		},
+		{Name: "lidar", Instance: "UART2", Baud: 115200, LogicVoltageV: 3.3, SupportedBaud: []int{115200}},
+	}
+	return spec
+}
+
+func boolPtr(b bool) *bool { return &b }
+
+func TestRuleUART(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*model.RobotSpec)
+		want   []string
+		not    []string
+	}{
+		{
+			name:   "gps_and_lidar_on_own_uarts",
+			mutate: func(s *model.RobotSpec) {},
+			not: []string{
+				"UART_SHARED", "UART_UNKNOWN", "UART_COUNT_EXCEEDED", "UART_BAUD_UNSUPPORTED", "UART_LOGIC_MISMATCH",
+			},
+		},
+		{
+			name: "two_devices_on_one_uart",
+			mutate: func(s *model.RobotSpec) {
+				s.UARTs[1].Instance = "uart1"
+			},
+			want: []string{"UART_SHARED"},
+		},
+		{
+			name: "instance_the_mcu_lacks",
+			mutate: func(s *model.RobotSpec) {
+				s.UARTs[1].Instance = "UART3"
+			},
+			want: []string{"UART_UNKNOWN"},
+		},
+		{
+			name: "more_uarts_than_mcu",
+			mutate: func(s *model.RobotSpec) {
+				s.MCU.UARTs = []string{"UART0"}
+				s.UARTs[0].Instance = "UART0"
+			},
+			want: []string{"UART_COUNT_EXCEEDED", "UART_UNKNOWN"},
+		},
+		{
+			name: "baud_device_cannot_do",
+			mutate: func(s *model.RobotSpec) {
+				s.UARTs[1].Baud = 256000
+			},
+			want: []string{"UART_BAUD_UNSUPPORTED"},
+		},
+		{
+			name: "baud_above_mcu_max",
+			mutate: func(s *model.RobotSpec) {
+				s.UARTs[0].SupportedBaud = nil
+				s.MCU.UARTMaxBaud = 2000000
+				s.UARTs[0].Baud = 3000000
+			},
+			want: []string{"UART_BAUD_UNSUPPORTED"},
+		},
+		{
+			name: "5v_ttl_gps_on_3v3_mcu",
+			mutate: func(s *model.RobotSpec) {
+				s.UARTs[0].LogicVoltageV = 5
+			},
+			want: []string{"UART_LOGIC_MISMATCH"},
+		},
+		{
+			name: "5v_ttl_gps_on_5v_tolerant_mcu",
+			mutate: func(s *model.RobotSpec) {
+				s.UARTs[0].LogicVoltageV = 5
+				s.MCU.FiveVTolerant = boolPtr(true)
+			},
+			not: []string{"UART_LOGIC_MISMATCH"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := uartSpec()
+			tt.mutate(&spec)
+			codes := reportCodes(RunAll(spec, nil))
+			for _, c := range tt.want {
+				requireHasCode(t, codes, c)
+			}
+			for _, c := range tt.not {
+				requireNoCode(t, codes, c)
+			}
+		})
+	}
+}
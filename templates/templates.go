@@ -13,6 +13,7 @@ var (
 	templateFiles = map[string]string{
 		"4wd-clean":   "4wd-clean.yaml",
 		"4wd-problem": "4wd-problem.yaml",
+		"stepper-arm": "stepper-arm.yaml",
 	}
 )
 